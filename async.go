@@ -0,0 +1,283 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Default asynchronous delivery settings.
+const (
+	DefaultQueueSize = 1024
+	DefaultWorkers   = 1
+)
+
+// OverflowPolicy controls what happens when an entry is delivered
+// asynchronously while the queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the event until there is room in the queue.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest drops the entry being queued.
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest queued entry to make room.
+	OverflowDropOldest
+)
+
+// String returns the name of the policy, as used in metric attributes.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropNewest:
+		return "drop_newest"
+	case OverflowDropOldest:
+		return "drop_oldest"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// AsyncConfig configures the asynchronous delivery of entries to callbacks
+// and sinks.
+type AsyncConfig struct {
+	// QueueSize is the number of entries that can be queued,
+	// DefaultQueueSize if not positive.
+	QueueSize int
+	// Workers is the number of goroutines delivering queued entries,
+	// DefaultWorkers if not positive. With more than one worker, entries
+	// may be delivered out of order.
+	Workers int
+	// Overflow is the policy applied when the queue is full.
+	Overflow OverflowPolicy
+}
+
+// WithAsyncDelivery makes the logger deliver entries to callbacks and sinks
+// from a pool of worker goroutines, so that slow receivers do not add
+// latency to events. Entries are deep-copied before they are queued, and
+// delivery errors are reported to the OpenTelemetry error handler instead
+// of being returned by OnAfterEvent.
+func WithAsyncDelivery(config AsyncConfig) Option {
+	return func(o *options) {
+		o.async = &config
+	}
+}
+
+// queuedEntry is an entry waiting to be delivered with the settings that
+// were current when it completed.
+type queuedEntry struct {
+	settings *settings
+	entry    *LogEntry
+}
+
+// dispatcher delivers entries from a bounded queue.
+type dispatcher struct {
+	// mu guards closing the queue against concurrent sends, which give up
+	// once stopping is closed so that closing never waits on a full queue.
+	mu       sync.RWMutex
+	closed   bool
+	stopping chan struct{}
+	queue    chan queuedEntry
+	overflow OverflowPolicy
+
+	// pending is the number of entries queued or being delivered.
+	pending atomic.Int64
+	workers sync.WaitGroup
+}
+
+// newDispatcher returns a dispatcher for the configuration.
+func newDispatcher(config AsyncConfig) *dispatcher {
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultQueueSize
+	}
+	return &dispatcher{
+		stopping: make(chan struct{}),
+		queue:    make(chan queuedEntry, config.QueueSize),
+		overflow: config.Overflow,
+	}
+}
+
+// enqueue queues the item according to the overflow policy and returns the
+// number of entries dropped. Items are dropped once the dispatcher is
+// closing.
+func (d *dispatcher) enqueue(item queuedEntry) int64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return 1
+	}
+
+	d.pending.Add(1)
+	switch d.overflow {
+	case OverflowDropNewest:
+		select {
+		case d.queue <- item:
+			return 0
+		default:
+			d.pending.Add(-1)
+			return 1
+		}
+	case OverflowDropOldest:
+		var dropped int64
+		for {
+			select {
+			case d.queue <- item:
+				return dropped
+			default:
+			}
+
+			select {
+			case <-d.queue:
+				d.pending.Add(-1)
+				dropped++
+			default:
+			}
+		}
+	default:
+		select {
+		case d.queue <- item:
+			return 0
+		case <-d.stopping:
+			d.pending.Add(-1)
+			return 1
+		}
+	}
+}
+
+// flush waits until all queued entries have been delivered or ctx is done.
+func (d *dispatcher) flush(ctx context.Context) error {
+	ticker := time.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+
+	for d.pending.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// close stops accepting entries and waits until the workers have delivered
+// the queued entries or ctx is done.
+func (d *dispatcher) close(ctx context.Context) error {
+	// Release sends blocked on a full queue before waiting for them
+	close(d.stopping)
+
+	done := make(chan struct{})
+	go func() {
+		d.mu.Lock()
+		d.closed = true
+		close(d.queue)
+		d.mu.Unlock()
+
+		d.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// startDispatcher starts the workers delivering queued entries and
+// registers the observation of the queue depth.
+func (l *OpenTelemetryLogger) startDispatcher(meter metric.Meter, config AsyncConfig) error {
+	l.dispatcher = newDispatcher(config)
+
+	registration, err := meter.RegisterCallback(l.observeQueue, l.queueDepth)
+	if err != nil {
+		return err
+	}
+	l.queueRegistration = registration
+
+	workers := config.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	l.dispatcher.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go l.deliverQueued()
+	}
+	return nil
+}
+
+// deliverQueued delivers queued entries until the queue is closed.
+func (l *OpenTelemetryLogger) deliverQueued() {
+	defer l.dispatcher.workers.Done()
+
+	for item := range l.dispatcher.queue {
+		if err := l.deliver(item.settings, item.entry); err != nil {
+			otel.Handle(fmt.Errorf("deliver log entry: %w", err))
+		}
+		l.dispatcher.pending.Add(-1)
+	}
+}
+
+// enqueue queues a deep copy of the entry for delivery, counting the
+// entries dropped because the queue is full.
+func (l *OpenTelemetryLogger) enqueue(s *settings, entry *LogEntry) {
+	dropped := l.dispatcher.enqueue(queuedEntry{settings: s, entry: copyEntry(entry)})
+	if dropped > 0 {
+		l.queueDropped.Add(l.ctx, dropped, l.withAttributes(
+			attribute.String("policy", l.dispatcher.overflow.String()),
+		))
+	}
+}
+
+// observeQueue observes the number of queued entries.
+func (l *OpenTelemetryLogger) observeQueue(ctx context.Context, observer metric.Observer) error {
+	observer.ObserveInt64(l.queueDepth, int64(len(l.dispatcher.queue)), l.withAttributes())
+	return nil
+}
+
+// copyEntry returns a deep copy of the entry, so that it can be delivered
+// after the caller has reused or modified the original.
+func copyEntry(entry *LogEntry) *LogEntry {
+	c := *entry
+	c.Explanations = copyRules(entry.Explanations)
+	c.Rules = copyRules(entry.Rules)
+	if entry.Requests != nil {
+		c.Requests = append([]EnforceRequest{}, entry.Requests...)
+	}
+	return &c
+}
+
+// copyRules returns a deep copy of the rules.
+func copyRules(rules [][]string) [][]string {
+	if rules == nil {
+		return nil
+	}
+
+	c := make([][]string, len(rules))
+	for i, rule := range rules {
+		c[i] = append([]string(nil), rule...)
+	}
+	return c
+}
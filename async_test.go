@@ -0,0 +1,188 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestCopyEntry(t *testing.T) {
+	entry := &LogEntry{
+		EventType:    EventBatchEnforce,
+		Rules:        [][]string{{"alice", "data1", "read"}},
+		Explanations: [][]string{{"p", "alice"}},
+		Requests:     []EnforceRequest{{Subject: "alice"}},
+	}
+
+	c := copyEntry(entry)
+	entry.Rules[0][0] = "bob"
+	entry.Explanations[0][1] = "bob"
+	entry.Requests[0].Subject = "bob"
+
+	if c.Rules[0][0] != "alice" || c.Explanations[0][1] != "alice" || c.Requests[0].Subject != "alice" {
+		t.Errorf("Expected the copy to be independent of the original, got %+v", c)
+	}
+}
+
+// blockingReceiver records the subjects of delivered entries, blocking
+// each delivery until released.
+type blockingReceiver struct {
+	mu       sync.Mutex
+	subjects []string
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func newBlockingReceiver() *blockingReceiver {
+	return &blockingReceiver{
+		started: make(chan struct{}, 16),
+		release: make(chan struct{}),
+	}
+}
+
+func (r *blockingReceiver) callback(entry *LogEntry) error {
+	r.started <- struct{}{}
+	<-r.release
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subjects = append(r.subjects, entry.Subject)
+	return nil
+}
+
+func (r *blockingReceiver) delivered() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.subjects...)
+}
+
+func TestAsyncDelivery_Overflow(t *testing.T) {
+	testCases := []struct {
+		overflow  OverflowPolicy
+		delivered []string
+	}{
+		{OverflowDropNewest, []string{"first", "second"}},
+		{OverflowDropOldest, []string{"first", "third"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.overflow.String(), func(t *testing.T) {
+			reader := metric.NewManualReader()
+			provider := metric.NewMeterProvider(metric.WithReader(reader))
+			meter := provider.Meter("test")
+
+			logger, err := NewOpenTelemetryLogger(meter, WithAsyncDelivery(AsyncConfig{
+				QueueSize: 1,
+				Workers:   1,
+				Overflow:  tc.overflow,
+			}))
+			if err != nil {
+				t.Fatalf("Setup failed: %v", err)
+			}
+
+			receiver := newBlockingReceiver()
+			logger.SetLogCallback(receiver.callback)
+
+			log := func(subject string) {
+				entry := &LogEntry{EventType: EventEnforce, Subject: subject}
+				logger.OnBeforeEvent(entry)
+				if err := logger.OnAfterEvent(entry); err != nil {
+					t.Errorf("OnAfterEvent returned error: %v", err)
+				}
+			}
+
+			// The worker blocks on the first entry, so the second fills
+			// the queue and the third overflows it
+			log("first")
+			<-receiver.started
+			log("second")
+			log("third")
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(context.Background(), &rm); err != nil {
+				t.Fatalf("Failed to collect metrics: %v", err)
+			}
+
+			depth, ok := findMetric(rm, "casbin.logger.queue.depth")
+			if !ok {
+				t.Fatal("Expected queue depth metric to be recorded")
+			}
+			if dps := depth.Data.(metricdata.Gauge[int64]).DataPoints; len(dps) != 1 || dps[0].Value != 1 {
+				t.Errorf("Expected a queue depth of 1, got %+v", dps)
+			}
+
+			if dropped := collectSum(t, reader, "casbin.logger.queue.dropped"); dropped != 1 {
+				t.Errorf("Expected 1 dropped entry, got %d", dropped)
+			}
+
+			close(receiver.release)
+			waitFor(t, func() bool { return len(receiver.delivered()) == len(tc.delivered) })
+
+			if got := receiver.delivered(); !reflect.DeepEqual(got, tc.delivered) {
+				t.Errorf("Expected delivered entries %v, got %v", tc.delivered, got)
+			}
+		})
+	}
+}
+
+func TestAsyncDelivery_Block(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithAsyncDelivery(AsyncConfig{Workers: 4}))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var mu sync.Mutex
+	delivered := 0
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered++
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				entry := &LogEntry{EventType: EventEnforce}
+				logger.OnBeforeEvent(entry)
+				logger.OnAfterEvent(entry)
+			}
+		}()
+	}
+	wg.Wait()
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return delivered == 4000
+	})
+
+	if dropped := collectSum(t, reader, "casbin.logger.queue.dropped"); dropped != 0 {
+		t.Errorf("Expected no dropped entries with the block policy, got %d", dropped)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// maxCachedAttributeSets bounds the number of attribute sets cached per
+// kind of measurement, so that an unbounded number of domains cannot grow
+// the caches without limit. Measurements beyond it compute their set.
+const maxCachedAttributeSets = 4096
+
+// enforceKey identifies the attributes of an enforce decision.
+type enforceKey struct {
+	allowed bool
+	domain  string
+}
+
+// eventKey identifies the attributes of the generic event metrics.
+type eventKey struct {
+	eventType EventType
+	domain    string
+}
+
+// measurementOptions holds the precomputed options of a measurement with
+// an attribute set, so that recording it does not allocate.
+type measurementOptions struct {
+	add    []metric.AddOption
+	record []metric.RecordOption
+}
+
+// newMeasurementOptions returns the options for the attribute set.
+func newMeasurementOptions(set attribute.Set) *measurementOptions {
+	opt := metric.WithAttributeSet(set)
+	return &measurementOptions{
+		add:    []metric.AddOption{opt},
+		record: []metric.RecordOption{opt},
+	}
+}
+
+// attributeCache is a bounded cache of measurement options. Lookups read
+// an immutable map without locking, while insertions publish a modified
+// copy, like the settings of the logger.
+type attributeCache[K comparable] struct {
+	mu      sync.Mutex
+	entries atomic.Pointer[map[K]*measurementOptions]
+}
+
+// lookup returns the cached options for the key.
+func (c *attributeCache[K]) lookup(key K) (*measurementOptions, bool) {
+	entries := c.entries.Load()
+	if entries == nil {
+		return nil, false
+	}
+	opts, ok := (*entries)[key]
+	return opts, ok
+}
+
+// store caches the options for the key unless the cache is full, and
+// returns the options cached for the key.
+func (c *attributeCache[K]) store(key K, opts *measurementOptions) *measurementOptions {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entries map[K]*measurementOptions
+	if p := c.entries.Load(); p != nil {
+		entries = *p
+	}
+
+	if cached, ok := entries[key]; ok {
+		return cached
+	}
+	if len(entries) >= maxCachedAttributeSets {
+		return opts
+	}
+
+	updated := make(map[K]*measurementOptions, len(entries)+1)
+	for k, v := range entries {
+		updated[k] = v
+	}
+	updated[key] = opts
+	c.entries.Store(&updated)
+	return opts
+}
+
+// enforceOptions returns the measurement options of an enforce decision.
+func (l *OpenTelemetryLogger) enforceOptions(allowed bool, domain string) *measurementOptions {
+	s := l.settings.Load()
+	key := enforceKey{allowed: allowed, domain: domain}
+	if opts, ok := s.enforceOptions.lookup(key); ok {
+		return opts
+	}
+
+	set := l.attributeSet(s, domain, enforceAttributes(allowed, domain)...)
+	return s.enforceOptions.store(key, newMeasurementOptions(set))
+}
+
+// eventOptions returns the measurement options of the generic event metrics.
+func (l *OpenTelemetryLogger) eventOptions(eventType EventType, domain string) *measurementOptions {
+	s := l.settings.Load()
+	key := eventKey{eventType: eventType, domain: domain}
+	if opts, ok := s.eventOptions.lookup(key); ok {
+		return opts
+	}
+
+	set := l.attributeSet(s, domain, attribute.String("event_type", string(eventType)))
+	return s.eventOptions.store(key, newMeasurementOptions(set))
+}
+
+// attributeSet returns the set of the attributes configured for the logger,
+// those of the domain override matching the domain and the given ones.
+func (l *OpenTelemetryLogger) attributeSet(s *settings, domain string, attrs ...attribute.KeyValue) attribute.Set {
+	var domainAttrs []attribute.KeyValue
+	if override := s.domainOverride(domain); override != nil {
+		domainAttrs = override.attributes
+	}
+
+	all := make([]attribute.KeyValue, 0, len(l.attributes)+len(domainAttrs)+len(attrs))
+	all = append(all, l.attributes...)
+	all = append(all, domainAttrs...)
+	all = append(all, attrs...)
+	return attribute.NewSet(all...)
+}
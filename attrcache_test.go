@@ -0,0 +1,167 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func newBenchmarkLogger(tb testing.TB) *OpenTelemetryLogger {
+	tb.Helper()
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithAttributes(attribute.String("service", "api")))
+	if err != nil {
+		tb.Fatalf("Setup failed: %v", err)
+	}
+	return logger
+}
+
+func TestRecordEnforceMetrics_NoAllocs(t *testing.T) {
+	logger := newBenchmarkLogger(t)
+	ctx := context.Background()
+	entry := &LogEntry{
+		EventType: EventEnforce,
+		Domain:    "domain1",
+		Allowed:   true,
+		Duration:  time.Millisecond,
+	}
+
+	// Warm up the attribute caches and the aggregations
+	logger.recordEnforceMetrics(ctx, entry)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.recordEnforceMetrics(ctx, entry)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations per enforce recording, got %v", allocs)
+	}
+}
+
+func TestAttributeCache_Invalidation(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	record := func() {
+		entry := &LogEntry{EventType: EventEnforce, Domain: "tenant1"}
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	record()
+	logger.SetDomainOverride("tenant*", DomainOverride{
+		Attributes: []attribute.KeyValue{attribute.String("tier", "gold")},
+	})
+	record()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	total, ok := findMetric(rm, "casbin.enforce.total")
+	if !ok {
+		t.Fatal("Expected enforce metrics to be recorded")
+	}
+
+	tiers := 0
+	dps := total.Data.(metricdata.Sum[int64]).DataPoints
+	for _, dp := range dps {
+		if _, ok := dp.Attributes.Value("tier"); ok {
+			tiers++
+		}
+	}
+	if len(dps) != 2 || tiers != 1 {
+		t.Errorf("Expected the override attributes to apply after the change, got %+v", dps)
+	}
+}
+
+func TestAttributeCache_Bounded(t *testing.T) {
+	logger := newBenchmarkLogger(t)
+	s := logger.settings.Load()
+
+	for i := 0; i < maxCachedAttributeSets+10; i++ {
+		logger.enforceOptions(true, fmt.Sprintf("domain%d", i))
+	}
+
+	if size := len(*s.enforceOptions.entries.Load()); size != maxCachedAttributeSets {
+		t.Errorf("Expected the cache to hold %d sets, got %d", maxCachedAttributeSets, size)
+	}
+
+	opts := logger.enforceOptions(false, "uncached")
+	if opts == nil || len(opts.add) != 1 {
+		t.Error("Expected options to be computed for uncached keys")
+	}
+}
+
+func BenchmarkRecordEnforceMetrics(b *testing.B) {
+	logger := newBenchmarkLogger(b)
+	ctx := context.Background()
+	entry := &LogEntry{
+		EventType: EventEnforce,
+		Domain:    "domain1",
+		Allowed:   true,
+		Duration:  time.Millisecond,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.recordEnforceMetrics(ctx, entry)
+	}
+}
+
+func BenchmarkRecordEnforceMetrics_Parallel(b *testing.B) {
+	logger := newBenchmarkLogger(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		entry := &LogEntry{EventType: EventEnforce, Domain: "domain1", Duration: time.Millisecond}
+		for pb.Next() {
+			entry.Allowed = !entry.Allowed
+			logger.recordEnforceMetrics(ctx, entry)
+		}
+	})
+}
+
+func BenchmarkEnforceEvent(b *testing.B) {
+	logger := newBenchmarkLogger(b)
+	entry := &LogEntry{EventType: EventEnforce, Domain: "domain1", Allowed: true}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+}
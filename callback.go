@@ -0,0 +1,171 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CallbackID identifies a callback added with AddLogCallback.
+type CallbackID uint64
+
+// CallbackErrorMode controls how delivery proceeds when a callback or sink
+// returns an error.
+type CallbackErrorMode int
+
+const (
+	// ContinueOnError delivers the entry to every callback and sink and
+	// returns their errors joined.
+	ContinueOnError CallbackErrorMode = iota
+	// StopOnError stops delivering the entry at the first callback or sink
+	// that returns an error, and returns that error.
+	StopOnError
+)
+
+// logCallback is a callback added with AddLogCallback.
+type logCallback struct {
+	id       CallbackID
+	callback func(entry *LogEntry) error
+}
+
+// AddLogCallback adds a callback for log entries and returns its ID, which
+// can be passed to RemoveLogCallback. Callbacks are called in the order
+// they were added, after the callback set with SetLogCallback and before
+// the sinks.
+func (l *OpenTelemetryLogger) AddLogCallback(callback func(entry *LogEntry) error) (CallbackID, error) {
+	if callback == nil {
+		return 0, errors.New("log callback must not be nil")
+	}
+
+	var id CallbackID
+	l.update(func(s *settings) {
+		l.lastCallbackID++
+		id = l.lastCallbackID
+		s.callbacks = append(s.callbacks, logCallback{id: id, callback: callback})
+	})
+	return id, nil
+}
+
+// RemoveLogCallback removes the callback with the given ID. Removing a
+// callback that is not registered does nothing.
+func (l *OpenTelemetryLogger) RemoveLogCallback(id CallbackID) error {
+	l.update(func(s *settings) {
+		callbacks := make([]logCallback, 0, len(s.callbacks))
+		for _, c := range s.callbacks {
+			if c.id != id {
+				callbacks = append(callbacks, c)
+			}
+		}
+		s.callbacks = callbacks
+	})
+	return nil
+}
+
+// SetCallbackErrorMode configures whether delivery continues after a
+// callback or sink returns an error. The default is ContinueOnError.
+func (l *OpenTelemetryLogger) SetCallbackErrorMode(mode CallbackErrorMode) error {
+	l.update(func(s *settings) {
+		s.callbackErrorMode = mode
+	})
+	return nil
+}
+
+// SetCallbackTimeout sets the time each callback may take to handle an
+// entry. A callback that times out is left running in the background with
+// its own copy of the entry, and delivery continues as if it had returned
+// an error. A zero timeout disables timeouts.
+func (l *OpenTelemetryLogger) SetCallbackTimeout(timeout time.Duration) error {
+	l.update(func(s *settings) {
+		s.callbackTimeout = timeout
+	})
+	return nil
+}
+
+// deliverTo calls the callbacks and then writes the entry to the sinks,
+// stopping at the first error if configured to.
+func (l *OpenTelemetryLogger) deliverTo(s *settings, entry *LogEntry, sinks []Sink) error {
+	var errs []error
+	handle := func(err error) bool {
+		if err == nil {
+			return true
+		}
+		errs = append(errs, err)
+		return s.callbackErrorMode != StopOnError
+	}
+
+	if s.callback != nil && !handle(l.runCallback(s, s.callback, entry)) {
+		return joinErrors(errs)
+	}
+
+	for _, c := range s.callbacks {
+		if !handle(l.runCallback(s, c.callback, entry)) {
+			return joinErrors(errs)
+		}
+	}
+
+	for _, sink := range sinks {
+		if !handle(l.safeCall(sink.Write, entry)) {
+			return joinErrors(errs)
+		}
+	}
+
+	return joinErrors(errs)
+}
+
+// runCallback calls the callback, giving up after the configured timeout.
+func (l *OpenTelemetryLogger) runCallback(s *settings, callback func(entry *LogEntry) error, entry *LogEntry) error {
+	if s.callbackTimeout <= 0 {
+		return l.safeCall(callback, entry)
+	}
+
+	// The callback may outlive the event, so it gets its own copy
+	entry = copyEntry(entry)
+	done := make(chan error, 1)
+	go func() {
+		done <- l.safeCall(callback, entry)
+	}()
+
+	timer := time.NewTimer(s.callbackTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		l.callbackFailed("timeout")
+		return fmt.Errorf("log callback timed out after %v", s.callbackTimeout)
+	}
+}
+
+// safeCall calls the callback, converting a panic into an error.
+func (l *OpenTelemetryLogger) safeCall(callback func(entry *LogEntry) error, entry *LogEntry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.callbackFailed("panic")
+			err = fmt.Errorf("log callback panicked: %v", r)
+		}
+	}()
+
+	return callback(entry)
+}
+
+// callbackFailed records a callback that panicked or timed out.
+func (l *OpenTelemetryLogger) callbackFailed(reason string) {
+	l.callbackFailures.Add(l.ctx, 1, l.withAttributes(attribute.String("reason", reason)))
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestAddLogCallback(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var calls []string
+	record := func(name string) func(entry *LogEntry) error {
+		return func(entry *LogEntry) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	first, err := logger.AddLogCallback(record("first"))
+	if err != nil {
+		t.Fatalf("AddLogCallback returned error: %v", err)
+	}
+	second, _ := logger.AddLogCallback(record("second"))
+	logger.AddLogCallback(record("third"))
+	logger.SetLogCallback(record("primary"))
+
+	if first == second {
+		t.Error("Expected distinct callback IDs")
+	}
+
+	entry := &LogEntry{EventType: EventEnforce}
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	expected := []string{"primary", "first", "second", "third"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+
+	logger.RemoveLogCallback(second)
+	logger.RemoveLogCallback(second)
+	calls = nil
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	expected = []string{"primary", "first", "third"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected calls %v after removal, got %v", expected, calls)
+	}
+
+	if _, err := logger.AddLogCallback(nil); err == nil {
+		t.Error("Expected an error for a nil callback")
+	}
+}
+
+func TestCallbackErrorMode(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	testCases := []struct {
+		name      string
+		mode      CallbackErrorMode
+		expected  []error
+		sinkCalls int
+	}{
+		{"Continue", ContinueOnError, []error{errFirst, errSecond}, 1},
+		{"Stop", StopOnError, []error{errFirst}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := metric.NewManualReader()
+			provider := metric.NewMeterProvider(metric.WithReader(reader))
+			meter := provider.Meter("test")
+
+			logger, err := NewOpenTelemetryLogger(meter, WithCallbackErrorMode(tc.mode))
+			if err != nil {
+				t.Fatalf("Setup failed: %v", err)
+			}
+
+			secondCalls := 0
+			logger.AddLogCallback(func(entry *LogEntry) error { return errFirst })
+			logger.AddLogCallback(func(entry *LogEntry) error {
+				secondCalls++
+				return errSecond
+			})
+
+			var buf bytes.Buffer
+			logger.AddSink(NewJSONSink(&buf))
+
+			entry := &LogEntry{EventType: EventEnforce}
+			logger.OnBeforeEvent(entry)
+			err = logger.OnAfterEvent(entry)
+
+			for _, expected := range tc.expected {
+				if !errors.Is(err, expected) {
+					t.Errorf("Expected error to wrap %v, got %v", expected, err)
+				}
+			}
+			if tc.mode == StopOnError && (secondCalls != 0 || errors.Is(err, errSecond)) {
+				t.Error("Expected delivery to stop at the first error")
+			}
+			if writes := strings.Count(buf.String(), "\n"); writes != tc.sinkCalls {
+				t.Errorf("Expected %d sink writes, got %d", tc.sinkCalls, writes)
+			}
+		})
+	}
+}
+
+func TestCallbackPanic(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		panic("instrumentation bug")
+	})
+
+	called := false
+	logger.AddLogCallback(func(entry *LogEntry) error {
+		called = true
+		return nil
+	})
+
+	entry := &LogEntry{EventType: EventEnforce}
+	logger.OnBeforeEvent(entry)
+	err = logger.OnAfterEvent(entry)
+
+	if err == nil || !strings.Contains(err.Error(), "instrumentation bug") {
+		t.Errorf("Expected the panic to be returned as an error, got %v", err)
+	}
+	if !called {
+		t.Error("Expected the following callbacks to be called")
+	}
+	if failures := collectSum(t, reader, "casbin.logger.callback.failures"); failures != 1 {
+		t.Errorf("Expected 1 callback failure, got %d", failures)
+	}
+}
+
+func TestCallbackTimeout(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithCallbackTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		<-release
+		return nil
+	})
+
+	entry := &LogEntry{EventType: EventEnforce}
+	logger.OnBeforeEvent(entry)
+
+	start := time.Now()
+	err = logger.OnAfterEvent(entry)
+	if err == nil {
+		t.Error("Expected an error for a callback that timed out")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected OnAfterEvent to return after the timeout, took %v", elapsed)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	failures, ok := findMetric(rm, "casbin.logger.callback.failures")
+	if !ok {
+		t.Fatal("Expected callback failures to be recorded")
+	}
+	dps := failures.Data.(metricdata.Sum[int64]).DataPoints
+	if len(dps) != 1 || dps[0].Value != 1 {
+		t.Fatalf("Expected 1 callback failure, got %+v", dps)
+	}
+	if reason, _ := dps[0].Attributes.Value("reason"); reason.AsString() != "timeout" {
+		t.Errorf("Expected reason timeout, got %q", reason.AsString())
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import "time"
+
+// Clock provides the start and end times of events.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to allow the use of ordinary functions as Clocks.
+type ClockFunc func() time.Time
+
+// Now calls f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// systemClock is the clock reading the system time.
+type systemClock struct{}
+
+// Now implements Clock.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// WithClock sets the clock providing the start and end times of events,
+// the system clock by default. A deterministic clock makes durations
+// reproducible in tests, and allows replaying events with their original
+// timestamps. A nil clock restores the system clock.
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		if clock == nil {
+			clock = systemClock{}
+		}
+		o.clock = clock
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// fakeClock is a clock that only moves when advanced.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestWithClock_Histogram(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	clock := newFakeClock()
+	logger, err := NewOpenTelemetryLogger(meter,
+		WithClock(clock),
+		WithDurationBuckets(0.01, 0.05, 0.1),
+	)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	durations := []time.Duration{5 * time.Millisecond, 20 * time.Millisecond, 200 * time.Millisecond}
+	for _, d := range durations {
+		entry := &LogEntry{EventType: EventEnforce, Allowed: true}
+		logger.OnBeforeEvent(entry)
+		clock.Advance(d)
+		logger.OnAfterEvent(entry)
+
+		if entry.Duration != d {
+			t.Errorf("Expected a duration of %v, got %v", d, entry.Duration)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	m, ok := findMetric(rm, "casbin.enforce.duration")
+	if !ok {
+		t.Fatal("Expected enforce duration to be recorded")
+	}
+
+	dps := m.Data.(metricdata.Histogram[float64]).DataPoints
+	if len(dps) != 1 {
+		t.Fatalf("Expected 1 data point, got %d", len(dps))
+	}
+	dp := dps[0]
+
+	if dp.Count != 3 {
+		t.Errorf("Expected a count of 3, got %d", dp.Count)
+	}
+	if math.Abs(dp.Sum-0.225) > 1e-9 {
+		t.Errorf("Expected a sum of 0.225, got %v", dp.Sum)
+	}
+	if expected := []uint64{1, 1, 0, 1}; !reflect.DeepEqual(dp.BucketCounts, expected) {
+		t.Errorf("Expected bucket counts %v, got %v", expected, dp.BucketCounts)
+	}
+	if minimum, _ := dp.Min.Value(); minimum != 0.005 {
+		t.Errorf("Expected a minimum of 0.005, got %v", minimum)
+	}
+	if maximum, _ := dp.Max.Value(); maximum != 0.2 {
+		t.Errorf("Expected a maximum of 0.2, got %v", maximum)
+	}
+}
+
+func TestWithClock_Replay(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	// Replay an event with its recorded timestamps
+	startTime := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	timestamps := []time.Time{startTime, startTime.Add(42 * time.Millisecond)}
+	logger, err := NewOpenTelemetryLogger(meter, WithClock(ClockFunc(func() time.Time {
+		now := timestamps[0]
+		timestamps = timestamps[1:]
+		return now
+	})))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var delivered *LogEntry
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		delivered = entry
+		return nil
+	})
+
+	handle, _ := logger.BeginEvent(&LogEntry{EventType: EventAddPolicy})
+	handle.End(&LogEntry{EventType: EventAddPolicy, RuleCount: 1})
+
+	if delivered == nil {
+		t.Fatal("Expected the entry to be delivered")
+	}
+	if !delivered.StartTime.Equal(startTime) || delivered.Duration != 42*time.Millisecond {
+		t.Errorf("Expected the recorded timestamps, got %v and %v", delivered.StartTime, delivered.Duration)
+	}
+}
@@ -0,0 +1,709 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Sampling types supported in configuration files.
+const (
+	SamplingProbability = "probability"
+	SamplingRateLimit   = "rate_limit"
+	SamplingSubjectHash = "subject_hash"
+)
+
+// Sink types supported in configuration files.
+const (
+	SinkStdout = "stdout"
+	SinkStderr = "stderr"
+	SinkFile   = "file"
+)
+
+// Config is the declarative configuration of a logger, usually loaded from
+// a JSON file with LoadConfig. Durations are strings such as "50ms".
+type Config struct {
+	// MetricPrefix is the prefix of the names of the built-in metrics.
+	MetricPrefix string `json:"metric_prefix,omitempty"`
+	// Attributes are added to every measurement of the built-in metrics.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// HistogramBuckets are the bucket boundaries of the duration histograms, in seconds.
+	HistogramBuckets []float64 `json:"histogram_buckets,omitempty"`
+
+	// EventTypes are the event types to log.
+	EventTypes []EventType `json:"event_types,omitempty"`
+	// ExcludedEventTypes are the event types never to log.
+	ExcludedEventTypes []EventType `json:"excluded_event_types,omitempty"`
+	// Filter restricts the entries to log.
+	Filter *FilterConfig `json:"filter,omitempty"`
+
+	// Sampling configures the sampling of delivered entries.
+	Sampling *SamplingConfig `json:"sampling,omitempty"`
+	// SlowThreshold is the global slow-event threshold.
+	SlowThreshold string `json:"slow_threshold,omitempty"`
+	// SlowThresholds are the slow-event thresholds per event type.
+	SlowThresholds map[EventType]string `json:"slow_thresholds,omitempty"`
+	// RedactedFields are the fields redacted from delivered entries.
+	RedactedFields []string `json:"redacted_fields,omitempty"`
+	// DebugSubjects are the subjects whose entries are always delivered.
+	DebugSubjects []string `json:"debug_subjects,omitempty"`
+	// Sinks are the sinks that entries are delivered to.
+	Sinks []SinkConfig `json:"sinks,omitempty"`
+	// Domains override the configuration for the domains matching their
+	// key. A key ending in "*" matches every domain with that prefix, and
+	// "*" matches every domain.
+	Domains map[string]DomainConfig `json:"domains,omitempty"`
+}
+
+// DomainConfig overrides the configuration for the domains matching a
+// pattern.
+type DomainConfig struct {
+	// EventTypes are the event types to log, replacing the global ones.
+	EventTypes []EventType `json:"event_types,omitempty"`
+	// Attributes are added to the measurements of the built-in metrics.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Sinks replace the global sinks. They are opened when the logger is
+	// created and are not changed by ApplyConfig, which restores them if a
+	// reloaded configuration removes the pattern and later adds it back.
+	Sinks []SinkConfig `json:"sinks,omitempty"`
+}
+
+// FilterConfig configures a filter that entries must pass. All configured
+// conditions must be met.
+type FilterConfig struct {
+	// Domains are the domains to log. A domain ending in "*" matches every
+	// domain with that prefix, and "*" matches every domain.
+	Domains []string `json:"domains,omitempty"`
+	// Subjects are the subjects to log.
+	Subjects []string `json:"subjects,omitempty"`
+	// DeniedOnly restricts enforce events to denied requests.
+	DeniedOnly bool `json:"denied_only,omitempty"`
+}
+
+// SamplingConfig configures the sampling of delivered entries.
+type SamplingConfig struct {
+	// Type is one of "probability", "rate_limit" and "subject_hash".
+	Type string `json:"type"`
+	// Rate is the sampled fraction, between 0 and 1, for the probability
+	// and subject hash samplers.
+	Rate float64 `json:"rate,omitempty"`
+	// PerSecond is the maximum number of entries per second for the rate
+	// limit sampler.
+	PerSecond int `json:"per_second,omitempty"`
+	// KeepDenied makes denied enforce requests bypass sampling.
+	KeepDenied bool `json:"keep_denied,omitempty"`
+	// KeepErrors makes entries with an error bypass sampling.
+	KeepErrors bool `json:"keep_errors,omitempty"`
+}
+
+// SinkConfig configures a sink that entries are delivered to as lines of JSON.
+type SinkConfig struct {
+	// Type is one of "stdout", "stderr" and "file".
+	Type string `json:"type"`
+	// Path is the path of the file for the file sink.
+	Path string `json:"path,omitempty"`
+}
+
+// ConfigError describes an invalid configuration key.
+type ConfigError struct {
+	// Key is the path of the offending key, such as "sampling.rate" or "sinks[0].path".
+	Key string
+	// Err describes the problem.
+	Err error
+}
+
+// Error implements error.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid config key %q: %v", e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// configErrorf returns a ConfigError for the key.
+func configErrorf(key string, format string, args ...interface{}) error {
+	return &ConfigError{Key: key, Err: fmt.Errorf(format, args...)}
+}
+
+// LoadConfig reads and validates the JSON configuration file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates a JSON configuration. Unknown keys are
+// rejected.
+func ParseConfig(data []byte) (*Config, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	config := &Config{}
+	if err := decoder.Decode(config); err != nil {
+		return nil, decodeError(data, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// decodeError converts JSON decoding errors to ConfigErrors where the
+// offending key is known.
+func decodeError(data []byte, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return configErrorf(typeErr.Field, "cannot use %s value as %s", typeErr.Value, typeErr.Type)
+	}
+
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return configErrorf(unknownKeyPath(data, strings.Trim(field, `"`)), "unknown key")
+	}
+
+	return fmt.Errorf("invalid config: %w", err)
+}
+
+// unknownKeyPath returns the path of the unknown key in the configuration,
+// such as "domains.acme.sinks[0].pth". The decoder only reports the name of
+// the key, which is returned if its path cannot be found.
+func unknownKeyPath(data []byte, key string) string {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return key
+	}
+
+	if path, ok := findUnknownKey(value, reflect.TypeOf(Config{}), "", key); ok {
+		return path
+	}
+	return key
+}
+
+// findUnknownKey walks the JSON value decoded into the type and returns the
+// path of the first object key named key that matches no field of the type.
+func findUnknownKey(value interface{}, t reflect.Type, path string, key string) (string, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct && t.Kind() != reflect.Map {
+			return "", false
+		}
+
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			keyPath := name
+			if path != "" {
+				keyPath = path + "." + name
+			}
+
+			elem := t
+			if t.Kind() == reflect.Map {
+				elem = t.Elem()
+			} else if field, ok := jsonField(t, name); ok {
+				elem = field.Type
+			} else {
+				if name == key {
+					return keyPath, true
+				}
+				continue
+			}
+
+			if found, ok := findUnknownKey(v[name], elem, keyPath, key); ok {
+				return found, true
+			}
+		}
+
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return "", false
+		}
+
+		for i, item := range v {
+			if found, ok := findUnknownKey(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), key); ok {
+				return found, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// jsonField returns the field of the struct type that the JSON key decodes
+// into, matching names case-insensitively like encoding/json.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		if strings.EqualFold(tag, name) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// Validate checks the configuration and returns a ConfigError for the first
+// invalid key.
+func (c *Config) Validate() error {
+	for key := range c.Attributes {
+		if strings.TrimSpace(key) == "" {
+			return configErrorf("attributes", "attribute keys must not be empty")
+		}
+	}
+
+	for i, bound := range c.HistogramBuckets {
+		if i > 0 && bound <= c.HistogramBuckets[i-1] {
+			return configErrorf(fmt.Sprintf("histogram_buckets[%d]", i), "bucket boundaries must be strictly increasing")
+		}
+	}
+
+	if err := validateEventTypes("event_types", c.EventTypes); err != nil {
+		return err
+	}
+
+	if err := validateEventTypes("excluded_event_types", c.ExcludedEventTypes); err != nil {
+		return err
+	}
+
+	if c.Filter != nil {
+		for i, domain := range c.Filter.Domains {
+			if domain == "" {
+				return configErrorf(fmt.Sprintf("filter.domains[%d]", i), "domain must not be empty")
+			}
+		}
+	}
+
+	if c.Sampling != nil {
+		if err := c.Sampling.validate("sampling"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := parseThreshold("slow_threshold", c.SlowThreshold); err != nil {
+		return err
+	}
+
+	for eventType, threshold := range c.SlowThresholds {
+		if _, err := parseThreshold("slow_thresholds."+string(eventType), threshold); err != nil {
+			return err
+		}
+	}
+
+	for i, field := range c.RedactedFields {
+		if err := validateField(field); err != nil {
+			return &ConfigError{Key: fmt.Sprintf("redacted_fields[%d]", i), Err: err}
+		}
+	}
+
+	for i, sink := range c.Sinks {
+		if err := sink.validate(fmt.Sprintf("sinks[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	for _, pattern := range c.domainPatterns() {
+		if pattern == "" {
+			return configErrorf("domains", "domain pattern must not be empty")
+		}
+		domain := c.Domains[pattern]
+		if err := domain.validate("domains." + pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// domainPatterns returns the patterns of the domain overrides in order.
+func (c *Config) domainPatterns() []string {
+	patterns := make([]string, 0, len(c.Domains))
+	for pattern := range c.Domains {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// validate checks the domain configuration.
+func (c *DomainConfig) validate(key string) error {
+	if err := validateEventTypes(key+".event_types", c.EventTypes); err != nil {
+		return err
+	}
+
+	for attrKey := range c.Attributes {
+		if strings.TrimSpace(attrKey) == "" {
+			return configErrorf(key+".attributes", "attribute keys must not be empty")
+		}
+	}
+
+	for i, sink := range c.Sinks {
+		if err := sink.validate(fmt.Sprintf("%s.sinks[%d]", key, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// override returns the domain override for the configuration, except sinks.
+func (c *DomainConfig) override() DomainOverride {
+	return DomainOverride{
+		EventTypes: c.EventTypes,
+		Attributes: stringAttributes(c.Attributes),
+	}
+}
+
+// validateEventTypes checks that no event type is empty.
+func validateEventTypes(key string, eventTypes []EventType) error {
+	for i, eventType := range eventTypes {
+		if eventType == "" {
+			return configErrorf(fmt.Sprintf("%s[%d]", key, i), "event type must not be empty")
+		}
+	}
+	return nil
+}
+
+// parseThreshold parses a non-negative duration, returning zero for an
+// empty value.
+func parseThreshold(key string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	threshold, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, &ConfigError{Key: key, Err: err}
+	}
+	if threshold < 0 {
+		return 0, configErrorf(key, "duration must not be negative")
+	}
+	return threshold, nil
+}
+
+// validate checks the sampling configuration.
+func (c *SamplingConfig) validate(key string) error {
+	switch c.Type {
+	case SamplingProbability, SamplingSubjectHash:
+		if c.Rate < 0 || c.Rate > 1 {
+			return configErrorf(key+".rate", "rate must be between 0 and 1")
+		}
+	case SamplingRateLimit:
+		if c.PerSecond <= 0 {
+			return configErrorf(key+".per_second", "per_second must be positive")
+		}
+	default:
+		return configErrorf(key+".type", "unknown sampling type %q", c.Type)
+	}
+	return nil
+}
+
+// sampler returns the sampler for the configuration.
+func (c *SamplingConfig) sampler() Sampler {
+	var sampler Sampler
+	switch c.Type {
+	case SamplingProbability:
+		sampler = NewProbabilitySampler(c.Rate)
+	case SamplingRateLimit:
+		sampler = NewRateLimitSampler(c.PerSecond)
+	case SamplingSubjectHash:
+		sampler = NewSubjectHashSampler(c.Rate)
+	}
+
+	if c.KeepDenied || c.KeepErrors {
+		sampler = AlwaysSampleFailures(sampler, c.KeepDenied, c.KeepErrors)
+	}
+	return sampler
+}
+
+// validate checks the sink configuration.
+func (c *SinkConfig) validate(key string) error {
+	switch c.Type {
+	case SinkStdout, SinkStderr:
+		return nil
+	case SinkFile:
+		if c.Path == "" {
+			return configErrorf(key+".path", "path is required for file sinks")
+		}
+		return nil
+	default:
+		return configErrorf(key+".type", "unknown sink type %q", c.Type)
+	}
+}
+
+// open returns the sink for the configuration.
+func (c *SinkConfig) open() (Sink, error) {
+	switch c.Type {
+	case SinkStdout:
+		return NewJSONSink(os.Stdout), nil
+	case SinkStderr:
+		return NewJSONSink(os.Stderr), nil
+	default:
+		return NewJSONFileSink(c.Path)
+	}
+}
+
+// filter returns the filter for the configuration.
+func (c *FilterConfig) filter() Filter {
+	var filters []Filter
+
+	if len(c.Domains) > 0 {
+		domains := c.Domains
+		filters = append(filters, func(entry *LogEntry) bool {
+			for _, pattern := range domains {
+				if matchDomain(pattern, entry.Domain) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	if len(c.Subjects) > 0 {
+		subjects := make(map[string]bool, len(c.Subjects))
+		for _, subject := range c.Subjects {
+			subjects[subject] = true
+		}
+		filters = append(filters, func(entry *LogEntry) bool {
+			return subjects[entry.Subject]
+		})
+	}
+
+	if c.DeniedOnly {
+		filters = append(filters, func(entry *LogEntry) bool {
+			if entry.EventType != EventEnforce && entry.EventType != EventBatchEnforce {
+				return true
+			}
+			// The decision is only known once the event has completed
+			return entry.EndTime.IsZero() || isDenied(entry)
+		})
+	}
+
+	return And(filters...)
+}
+
+// matchDomain reports whether the domain matches the pattern. A pattern
+// ending in "*" matches every domain with that prefix.
+func matchDomain(pattern string, domain string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(domain, prefix)
+	}
+	return pattern == domain
+}
+
+// options returns the logger options for the configuration, except sinks.
+func (c *Config) options() []Option {
+	var opts []Option
+
+	if c.MetricPrefix != "" {
+		opts = append(opts, WithMetricPrefix(c.MetricPrefix))
+	}
+
+	if len(c.Attributes) > 0 {
+		opts = append(opts, WithAttributes(stringAttributes(c.Attributes)...))
+	}
+
+	if len(c.HistogramBuckets) > 0 {
+		opts = append(opts, WithDurationBuckets(c.HistogramBuckets...))
+	}
+
+	return append(opts, c.runtimeOptions()...)
+}
+
+// stringAttributes returns the attributes for a map of string values.
+func stringAttributes(values map[string]string) []attribute.KeyValue {
+	if len(values) == 0 {
+		return nil
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(values))
+	for key, value := range values {
+		attrs = append(attrs, attribute.String(key, value))
+	}
+	return attrs
+}
+
+// openSinks opens the global sinks of the configuration. If a sink cannot
+// be opened, the sinks opened before it are closed.
+func (c *Config) openSinks() ([]Sink, error) {
+	return openSinkConfigs("sinks", c.Sinks)
+}
+
+// openDomainSinks opens the sinks of the domain overrides, by pattern. If
+// a sink cannot be opened, the sinks opened before it are closed.
+func (c *Config) openDomainSinks() (map[string][]Sink, error) {
+	domainSinks := make(map[string][]Sink)
+	for _, pattern := range c.domainPatterns() {
+		sinkConfigs := c.Domains[pattern].Sinks
+		if sinkConfigs == nil {
+			continue
+		}
+
+		sinks, err := openSinkConfigs("domains."+pattern+".sinks", sinkConfigs)
+		if err != nil {
+			for _, opened := range domainSinks {
+				closeSinks(opened)
+			}
+			return nil, err
+		}
+		domainSinks[pattern] = sinks
+	}
+	return domainSinks, nil
+}
+
+// openSinkConfigs opens the sinks of the configurations. If a sink cannot
+// be opened, the sinks opened before it are closed.
+func openSinkConfigs(key string, sinkConfigs []SinkConfig) ([]Sink, error) {
+	var sinks []Sink
+	for i, sinkConfig := range sinkConfigs {
+		sink, err := sinkConfig.open()
+		if err != nil {
+			closeSinks(sinks)
+			return nil, &ConfigError{Key: fmt.Sprintf("%s[%d]", key, i), Err: err}
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// closeSinks closes the sinks, returning their errors joined.
+func closeSinks(sinks []Sink) error {
+	var errs []error
+	for _, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runtimeOptions returns the options for the settings of the configuration
+// that can be changed while the logger is running.
+func (c *Config) runtimeOptions() []Option {
+	var opts []Option
+
+	if c.EventTypes != nil {
+		opts = append(opts, WithEventTypes(c.EventTypes...))
+	}
+
+	if c.ExcludedEventTypes != nil {
+		opts = append(opts, WithExcludedEventTypes(c.ExcludedEventTypes...))
+	}
+
+	if c.Filter != nil {
+		opts = append(opts, WithFilter(c.Filter.filter()))
+	}
+
+	if c.Sampling != nil {
+		opts = append(opts, WithSampler(c.Sampling.sampler()))
+	}
+
+	// Thresholds have been validated, so parsing cannot fail
+	if threshold, _ := parseThreshold("slow_threshold", c.SlowThreshold); threshold > 0 {
+		opts = append(opts, WithSlowThreshold(threshold))
+	}
+
+	for eventType, value := range c.SlowThresholds {
+		threshold, _ := parseThreshold("slow_thresholds."+string(eventType), value)
+		opts = append(opts, WithSlowThresholdFor(eventType, threshold))
+	}
+
+	if c.RedactedFields != nil {
+		opts = append(opts, WithRedactedFields(c.RedactedFields...))
+	}
+
+	if c.DebugSubjects != nil {
+		opts = append(opts, WithDebugSubjects(c.DebugSubjects...))
+	}
+
+	for pattern, domain := range c.Domains {
+		opts = append(opts, WithDomainOverride(pattern, domain.override()))
+	}
+
+	return opts
+}
+
+// NewOpenTelemetryLoggerFromConfig creates a new OpenTelemetryLogger from the
+// configuration. Options passed explicitly take precedence over the
+// configuration, which takes precedence over the environment.
+func NewOpenTelemetryLoggerFromConfig(meter metric.Meter, config *Config, opts ...Option) (*OpenTelemetryLogger, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	sinks, err := config.openSinks()
+	if err != nil {
+		return nil, err
+	}
+
+	domainSinks, err := config.openDomainSinks()
+	if err != nil {
+		closeSinks(sinks)
+		return nil, err
+	}
+
+	configOpts := append(config.options(), WithSinks(sinks...), withDomainSinks(domainSinks))
+	logger, err := NewOpenTelemetryLogger(meter, append(configOpts, opts...)...)
+	if err != nil {
+		closeSinks(sinks)
+		for _, opened := range domainSinks {
+			closeSinks(opened)
+		}
+		return nil, err
+	}
+
+	// Only the options passed by the caller take precedence over reloads
+	logger.explicitOptions = opts
+	logger.domainSinks = domainSinks
+
+	logger.recordConfigVersion()
+	return logger, nil
+}
+
+// NewOpenTelemetryLoggerFromFile creates a new OpenTelemetryLogger from the
+// JSON configuration file at path.
+func NewOpenTelemetryLoggerFromFile(meter metric.Meter, path string, opts ...Option) (*OpenTelemetryLogger, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewOpenTelemetryLoggerFromConfig(meter, config, opts...)
+}
@@ -0,0 +1,289 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestParseConfig_Errors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		key    string
+	}{
+		{"Unknown key", `{"sample_rate": 0.5}`, "sample_rate"},
+		{"Unknown nested key", `{"sampling": {"rat": 0.5}}`, "sampling.rat"},
+		{"Unknown domain sink key", `{"domains": {"a*": {"sinks": [{"type": "file", "pth": "a.log"}]}}}`, "domains.a*.sinks[0].pth"},
+		{"Wrong type", `{"sampling": {"type": "probability", "rate": "high"}}`, "sampling.rate"},
+		{"Unknown sampling type", `{"sampling": {"type": "random"}}`, "sampling.type"},
+		{"Rate out of range", `{"sampling": {"type": "probability", "rate": 2}}`, "sampling.rate"},
+		{"Missing per second", `{"sampling": {"type": "rate_limit"}}`, "sampling.per_second"},
+		{"Invalid threshold", `{"slow_threshold": "fast"}`, "slow_threshold"},
+		{"Invalid event threshold", `{"slow_thresholds": {"enforce": "-1s"}}`, "slow_thresholds.enforce"},
+		{"Unsorted buckets", `{"histogram_buckets": [0.1, 0.05]}`, "histogram_buckets[1]"},
+		{"Empty event type", `{"event_types": ["enforce", ""]}`, "event_types[1]"},
+		{"Unknown redacted field", `{"redacted_fields": ["password"]}`, "redacted_fields[0]"},
+		{"Unknown sink type", `{"sinks": [{"type": "stdout"}, {"type": "kafka"}]}`, "sinks[1].type"},
+		{"Missing sink path", `{"sinks": [{"type": "file"}]}`, "sinks[0].path"},
+		{"Empty filter domain", `{"filter": {"domains": [""]}}`, "filter.domains[0]"},
+		{"Empty domain pattern", `{"domains": {"": {}}}`, "domains"},
+		{"Empty domain event type", `{"domains": {"acme*": {"event_types": [""]}}}`, "domains.acme*.event_types[0]"},
+		{"Unknown domain sink type", `{"domains": {"acme": {"sinks": [{"type": "kafka"}]}}}`, "domains.acme.sinks[0].type"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tc.config))
+
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("Expected a ConfigError, got %v", err)
+			}
+
+			if configErr.Key != tc.key {
+				t.Errorf("Expected error for key %q, got %q (%v)", tc.key, configErr.Key, err)
+			}
+		})
+	}
+}
+
+func TestParseConfig_Syntax(t *testing.T) {
+	_, err := ParseConfig([]byte(`{"event_types": [`))
+	if err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
+
+func TestNewOpenTelemetryLoggerFromFile(t *testing.T) {
+	dir := t.TempDir()
+	auditPath := filepath.Join(dir, "audit.log")
+
+	config := map[string]interface{}{
+		"metric_prefix":     "authz",
+		"attributes":        map[string]string{"service": "api"},
+		"histogram_buckets": []float64{0.001, 0.01, 0.1},
+		"event_types":       []string{"enforce", "addPolicy"},
+		"filter": map[string]interface{}{
+			"domains": []string{"prod-*"},
+		},
+		"sampling": map[string]interface{}{
+			"type":        "probability",
+			"rate":        0,
+			"keep_denied": true,
+		},
+		"slow_thresholds": map[string]string{"addPolicy": "1h"},
+		"redacted_fields": []string{"subject"},
+		"sinks": []map[string]string{
+			{"type": "file", "path": auditPath},
+		},
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+
+	configPath := filepath.Join(dir, "logger.json")
+	if err := os.WriteFile(configPath, data, 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLoggerFromFile(meter, configPath)
+	if err != nil {
+		t.Fatalf("NewOpenTelemetryLoggerFromFile returned error: %v", err)
+	}
+
+	entries := []*LogEntry{
+		{EventType: EventEnforce, Subject: "alice", Domain: "prod-eu", Allowed: true},
+		{EventType: EventEnforce, Subject: "bob", Domain: "prod-eu", Allowed: false},
+		{EventType: EventEnforce, Subject: "carol", Domain: "dev", Allowed: false},
+		{EventType: EventAddPolicy, Domain: "prod-eu"},
+		{EventType: EventSavePolicy, Domain: "prod-eu"},
+	}
+	for _, entry := range entries {
+		logger.OnBeforeEvent(entry)
+		if err := logger.OnAfterEvent(entry); err != nil {
+			t.Errorf("OnAfterEvent returned error: %v", err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	total, ok := findMetric(rm, "authz.enforce.total")
+	if !ok {
+		t.Fatal("Expected enforce metrics with the configured prefix")
+	}
+	var count int64
+	for _, dp := range total.Data.(metricdata.Sum[int64]).DataPoints {
+		service, _ := dp.Attributes.Value("service")
+		if service.AsString() != "api" {
+			t.Errorf("Expected configured attributes, got %v", dp.Attributes.ToSlice())
+		}
+		count += dp.Value
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 enforce requests in prod domains, got %d", count)
+	}
+
+	duration, ok := findMetric(rm, "authz.enforce.duration")
+	if !ok {
+		t.Fatal("Expected enforce duration metric")
+	}
+	bounds := duration.Data.(metricdata.Histogram[float64]).DataPoints[0].Bounds
+	if len(bounds) != 3 || bounds[2] != 0.1 {
+		t.Errorf("Expected configured histogram buckets, got %v", bounds)
+	}
+
+	audit, err := os.ReadFile(auditPath)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+
+	// Only the denial bypasses the zero sampling rate
+	lines := strings.Split(strings.TrimSpace(string(audit)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 audit record, got %d: %s", len(lines), audit)
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("Failed to parse audit record: %v", err)
+	}
+	if record["subject"] != RedactedValue || record["allowed"] != false || record["domain"] != "prod-eu" {
+		t.Errorf("Unexpected audit record: %v", record)
+	}
+
+	s := logger.settings.Load()
+	if s.slowThresholds[EventAddPolicy] != time.Hour {
+		t.Errorf("Expected slow threshold for addPolicy, got %v", s.slowThresholds)
+	}
+
+	closeSinks(s.sinks)
+}
+
+func TestNewOpenTelemetryLoggerFromFile_Missing(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	_, err := NewOpenTelemetryLoggerFromFile(meter, filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a not exist error, got %v", err)
+	}
+}
+
+func TestFilterConfig_DeniedOnly(t *testing.T) {
+	filter := (&FilterConfig{DeniedOnly: true}).filter()
+
+	testCases := []struct {
+		name     string
+		entry    *LogEntry
+		expected bool
+	}{
+		{"Before outcome", &LogEntry{EventType: EventEnforce, Allowed: true}, true},
+		{"Allowed", &LogEntry{EventType: EventEnforce, Allowed: true, EndTime: time.Now()}, false},
+		{"Denied", &LogEntry{EventType: EventEnforce, EndTime: time.Now()}, true},
+		{"Batch before outcome", &LogEntry{EventType: EventBatchEnforce}, true},
+		{"Other events", &LogEntry{EventType: EventAddPolicy, EndTime: time.Now()}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := filter(tc.entry); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestFilterConfig_DeniedOnlyReusedEntry(t *testing.T) {
+	testCases := []struct {
+		name string
+		log  func(logger *OpenTelemetryLogger, entry *LogEntry, allowed bool)
+	}{
+		{"OnBeforeEvent", func(logger *OpenTelemetryLogger, entry *LogEntry, allowed bool) {
+			logger.OnBeforeEvent(entry)
+			entry.Allowed = allowed
+			logger.OnAfterEvent(entry)
+		}},
+		{"BeginEvent", func(logger *OpenTelemetryLogger, entry *LogEntry, allowed bool) {
+			handle, _ := logger.BeginEvent(entry)
+			entry.Allowed = allowed
+			entry.EndTime = time.Now()
+			handle.End(entry)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := metric.NewManualReader()
+			provider := metric.NewMeterProvider(metric.WithReader(reader))
+			meter := provider.Meter("test")
+
+			logger, err := NewOpenTelemetryLogger(meter, WithFilter((&FilterConfig{DeniedOnly: true}).filter()))
+			if err != nil {
+				t.Fatalf("NewOpenTelemetryLogger returned error: %v", err)
+			}
+
+			// The entry still carries the outcome of the allowed request
+			// when the denied one begins
+			entry := &LogEntry{EventType: EventEnforce, Subject: "alice"}
+			tc.log(logger, entry, true)
+			tc.log(logger, entry, false)
+
+			if count := collectSum(t, reader, "casbin.enforce.total"); count != 1 {
+				t.Errorf("Expected the denied request to be recorded, got %d requests", count)
+			}
+		})
+	}
+}
+
+func TestMatchDomain(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		domain   string
+		expected bool
+	}{
+		{"prod", "prod", true},
+		{"prod", "prod-eu", false},
+		{"prod-*", "prod-eu", true},
+		{"prod-*", "dev", false},
+		{"*", "", true},
+	}
+
+	for _, tc := range testCases {
+		if got := matchDomain(tc.pattern, tc.domain); got != tc.expected {
+			t.Errorf("matchDomain(%q, %q): expected %v, got %v", tc.pattern, tc.domain, tc.expected, got)
+		}
+	}
+}
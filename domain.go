@@ -0,0 +1,117 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DomainOverride overrides the global configuration for the entries of the
+// domains matching a pattern.
+type DomainOverride struct {
+	// EventTypes are the event types to log for the domains, replacing the
+	// global event types and exclusions. Nil keeps the global event types.
+	EventTypes []EventType
+	// Attributes are added to the measurements of the built-in metrics for
+	// the domains, after the attributes configured for the logger.
+	Attributes []attribute.KeyValue
+	// Sinks replace the global sinks for the domains. Nil keeps the global
+	// sinks, while an empty slice delivers entries to no sink.
+	Sinks []Sink
+}
+
+// domainOverride is the runtime form of a DomainOverride.
+type domainOverride struct {
+	// eventTypes is nil if the global event types apply.
+	eventTypes map[EventType]bool
+	attributes []attribute.KeyValue
+	// sinks is nil if the global sinks apply.
+	sinks []Sink
+}
+
+// newDomainOverride returns the runtime form of the override.
+func newDomainOverride(override DomainOverride) *domainOverride {
+	d := &domainOverride{
+		attributes: append([]attribute.KeyValue(nil), override.Attributes...),
+	}
+	if override.EventTypes != nil {
+		d.eventTypes = eventTypeSet(override.EventTypes)
+	}
+	if override.Sinks != nil {
+		d.sinks = append([]Sink{}, override.Sinks...)
+	}
+	return d
+}
+
+// SetDomainOverride overrides the event types, metric attributes and sinks
+// for the entries whose domain matches the pattern. A pattern ending in "*"
+// matches every domain with that prefix, and "*" matches every domain,
+// including the empty one. An exact pattern takes precedence over prefix
+// patterns, and longer prefixes over shorter ones. Setting an override for
+// a pattern replaces the previous one.
+func (l *OpenTelemetryLogger) SetDomainOverride(pattern string, override DomainOverride) error {
+	if pattern == "" {
+		return errors.New("domain pattern must not be empty")
+	}
+
+	d := newDomainOverride(override)
+	l.update(func(s *settings) {
+		s.domains[pattern] = d
+	})
+	return nil
+}
+
+// RemoveDomainOverride removes the override for the pattern, so the global
+// configuration applies again to the domains it matched.
+func (l *OpenTelemetryLogger) RemoveDomainOverride(pattern string) error {
+	l.update(func(s *settings) {
+		delete(s.domains, pattern)
+	})
+	return nil
+}
+
+// domainOverride returns the override matching the domain, or nil if there
+// is none.
+func (s *settings) domainOverride(domain string) *domainOverride {
+	if len(s.domains) == 0 {
+		return nil
+	}
+
+	if override, ok := s.domains[domain]; ok {
+		return override
+	}
+
+	var match *domainOverride
+	longest := -1
+	for pattern, override := range s.domains {
+		prefix, ok := strings.CutSuffix(pattern, "*")
+		if ok && len(prefix) > longest && strings.HasPrefix(domain, prefix) {
+			match = override
+			longest = len(prefix)
+		}
+	}
+	return match
+}
+
+// sinksFor returns the sinks that entries of the domain are delivered to.
+func (s *settings) sinksFor(domain string) []Sink {
+	if override := s.domainOverride(domain); override != nil && override.sinks != nil {
+		return override.sinks
+	}
+	return s.sinks
+}
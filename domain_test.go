@@ -0,0 +1,227 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestDomainOverride_Matching(t *testing.T) {
+	regulated := &domainOverride{}
+	bank := &domainOverride{}
+	bankEU := &domainOverride{}
+	all := &domainOverride{}
+
+	s := newSettings()
+	s.domains["regulated"] = regulated
+	s.domains["bank-*"] = bank
+	s.domains["bank-eu-*"] = bankEU
+	s.domains["*"] = all
+
+	testCases := []struct {
+		domain   string
+		expected *domainOverride
+	}{
+		{"regulated", regulated},
+		{"bank-us-1", bank},
+		{"bank-eu-1", bankEU},
+		{"retail", all},
+		{"", all},
+	}
+
+	for _, tc := range testCases {
+		if got := s.domainOverride(tc.domain); got != tc.expected {
+			t.Errorf("Unexpected override for domain %q", tc.domain)
+		}
+	}
+
+	delete(s.domains, "*")
+	if s.domainOverride("retail") != nil {
+		t.Error("Expected no override for an unmatched domain")
+	}
+}
+
+func TestSetDomainOverride(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithEventTypes(EventEnforce))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var regulatedBuf, globalBuf bytes.Buffer
+	logger.AddSink(NewJSONSink(&globalBuf))
+
+	err = logger.SetDomainOverride("regulated-*", DomainOverride{
+		EventTypes: []EventType{EventEnforce, EventAddPolicy},
+		Attributes: []attribute.KeyValue{attribute.String("tier", "regulated")},
+		Sinks:      []Sink{NewJSONSink(&regulatedBuf)},
+	})
+	if err != nil {
+		t.Fatalf("SetDomainOverride returned error: %v", err)
+	}
+
+	if err := logger.SetDomainOverride("", DomainOverride{}); err == nil {
+		t.Error("Expected an error for an empty pattern")
+	}
+
+	events := []*LogEntry{
+		{EventType: EventEnforce, Domain: "regulated-bank", Allowed: true},
+		{EventType: EventAddPolicy, Domain: "regulated-bank", RuleCount: 1},
+		{EventType: EventEnforce, Domain: "retail", Allowed: true},
+		{EventType: EventAddPolicy, Domain: "retail", RuleCount: 1},
+	}
+	for _, entry := range events {
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	if lines := strings.Count(regulatedBuf.String(), "\n"); lines != 2 {
+		t.Errorf("Expected 2 entries in the domain sink, got %d", lines)
+	}
+	if lines := strings.Count(globalBuf.String(), "\n"); lines != 1 {
+		t.Errorf("Expected 1 entry in the global sink, got %d", lines)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	if _, ok := findMetric(rm, "casbin.policy.operations.total"); !ok {
+		t.Error("Expected policy metrics for the overridden domain")
+	}
+
+	total, ok := findMetric(rm, "casbin.enforce.total")
+	if !ok {
+		t.Fatal("Expected enforce metrics to be recorded")
+	}
+	for _, dp := range total.Data.(metricdata.Sum[int64]).DataPoints {
+		domain, _ := dp.Attributes.Value("domain")
+		tier, hasTier := dp.Attributes.Value("tier")
+		switch domain.AsString() {
+		case "regulated-bank":
+			if !hasTier || tier.AsString() != "regulated" {
+				t.Errorf("Expected the domain attributes, got %v", dp.Attributes.ToSlice())
+			}
+		case "retail":
+			if hasTier {
+				t.Errorf("Expected no domain attributes, got %v", dp.Attributes.ToSlice())
+			}
+		}
+	}
+
+	logger.RemoveDomainOverride("regulated-*")
+	entry := &LogEntry{EventType: EventAddPolicy, Domain: "regulated-bank"}
+	logger.OnBeforeEvent(entry)
+	if entry.IsActive {
+		t.Error("Expected the global event types to apply after removing the override")
+	}
+}
+
+func TestSetDomainOverride_RedactedDomain(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	var tenantBuf, globalBuf bytes.Buffer
+	logger, err := NewOpenTelemetryLogger(meter,
+		WithRedactedFields(FieldDomain),
+		WithSinks(NewJSONSink(&globalBuf)),
+	)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	logger.SetDomainOverride("tenant1", DomainOverride{
+		Sinks: []Sink{NewJSONSink(&tenantBuf)},
+	})
+
+	entry := &LogEntry{EventType: EventEnforce, Domain: "tenant1", Allowed: true}
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	// The sinks are chosen by the domain before it is redacted
+	if lines := strings.Count(tenantBuf.String(), "\n"); lines != 1 {
+		t.Errorf("Expected 1 entry in the domain sink, got %d", lines)
+	}
+	if globalBuf.Len() != 0 {
+		t.Errorf("Expected no entry in the global sink, got %s", globalBuf.String())
+	}
+	if strings.Contains(tenantBuf.String(), "tenant1") {
+		t.Errorf("Expected the domain to be redacted, got %s", tenantBuf.String())
+	}
+}
+
+func TestConfig_Domains(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	auditPath := filepath.Join(t.TempDir(), "audit.log")
+	config, err := ParseConfig([]byte(`{
+		"event_types": ["enforce"],
+		"domains": {
+			"regulated-*": {
+				"event_types": ["enforce", "addPolicy"],
+				"attributes": {"tier": "regulated"},
+				"sinks": [{"type": "file", "path": "` + filepath.ToSlash(auditPath) + `"}]
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseConfig returned error: %v", err)
+	}
+
+	logger, err := NewOpenTelemetryLoggerFromConfig(meter, config)
+	if err != nil {
+		t.Fatalf("NewOpenTelemetryLoggerFromConfig returned error: %v", err)
+	}
+
+	s := logger.settings.Load()
+	if !s.eventTypeEnabled(EventAddPolicy, "regulated-bank") || s.eventTypeEnabled(EventAddPolicy, "retail") {
+		t.Error("Expected the domain event types to apply to matching domains only")
+	}
+	sinks := s.sinksFor("regulated-bank")
+	if len(sinks) != 1 {
+		t.Fatalf("Expected 1 domain sink, got %d", len(sinks))
+	}
+
+	// Reloading keeps the sinks opened with the logger
+	config.Domains["regulated-*"] = DomainConfig{EventTypes: []EventType{EventEnforce}}
+	if err := logger.ApplyConfig(config); err != nil {
+		t.Fatalf("ApplyConfig returned error: %v", err)
+	}
+
+	s = logger.settings.Load()
+	if s.eventTypeEnabled(EventAddPolicy, "regulated-bank") {
+		t.Error("Expected the reloaded domain event types to apply")
+	}
+	if got := s.sinksFor("regulated-bank"); len(got) != 1 || got[0] != sinks[0] {
+		t.Error("Expected the domain sinks to be kept on reload")
+	}
+
+	closeSinks(sinks)
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Environment variables used to configure the logger.
+const (
+	// EnvEventTypes is a comma-separated list of event types to log.
+	EnvEventTypes = "CASBIN_OTEL_EVENT_TYPES"
+	// EnvExcludedEventTypes is a comma-separated list of event types never to log.
+	EnvExcludedEventTypes = "CASBIN_OTEL_EXCLUDED_EVENT_TYPES"
+	// EnvSampleRate is the probability, between 0 and 1, with which entries
+	// are delivered to callbacks.
+	EnvSampleRate = "CASBIN_OTEL_SAMPLE_RATE"
+	// EnvSlowThreshold is the global slow-event threshold, such as "50ms".
+	EnvSlowThreshold = "CASBIN_OTEL_SLOW_THRESHOLD"
+	// EnvMetricPrefix is the prefix of the names of the built-in metrics.
+	EnvMetricPrefix = "CASBIN_OTEL_METRIC_PREFIX"
+	// EnvAttributes is a comma-separated list of key=value attributes added
+	// to every measurement, in the format of OTEL_RESOURCE_ATTRIBUTES.
+	EnvAttributes = "CASBIN_OTEL_ATTRIBUTES"
+)
+
+// envOptions returns the options configured by the environment variables.
+// As in the OpenTelemetry SDK, invalid values are reported to the global
+// error handler and ignored.
+func envOptions() []Option {
+	var opts []Option
+
+	if value, ok := lookupEnv(EnvEventTypes); ok {
+		opts = append(opts, WithEventTypes(parseEventTypes(value)...))
+	}
+
+	if value, ok := lookupEnv(EnvExcludedEventTypes); ok {
+		opts = append(opts, WithExcludedEventTypes(parseEventTypes(value)...))
+	}
+
+	if value, ok := lookupEnv(EnvSampleRate); ok {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			otel.Handle(fmt.Errorf("invalid %s %q: must be a number between 0 and 1", EnvSampleRate, value))
+		} else {
+			opts = append(opts, WithSampler(NewProbabilitySampler(rate)))
+		}
+	}
+
+	if value, ok := lookupEnv(EnvSlowThreshold); ok {
+		threshold, err := time.ParseDuration(value)
+		if err != nil || threshold < 0 {
+			otel.Handle(fmt.Errorf("invalid %s %q: must be a non-negative duration", EnvSlowThreshold, value))
+		} else {
+			opts = append(opts, WithSlowThreshold(threshold))
+		}
+	}
+
+	if value, ok := lookupEnv(EnvMetricPrefix); ok {
+		opts = append(opts, WithMetricPrefix(value))
+	}
+
+	if value, ok := lookupEnv(EnvAttributes); ok {
+		attrs, err := parseAttributes(value)
+		if err != nil {
+			otel.Handle(fmt.Errorf("invalid %s: %w", EnvAttributes, err))
+		} else {
+			opts = append(opts, WithAttributes(attrs...))
+		}
+	}
+
+	return opts
+}
+
+// lookupEnv returns the trimmed value of the environment variable, if it is
+// set to a non-empty value.
+func lookupEnv(key string) (string, bool) {
+	value := strings.TrimSpace(os.Getenv(key))
+	return value, value != ""
+}
+
+// parseEventTypes parses a comma-separated list of event types.
+func parseEventTypes(value string) []EventType {
+	var eventTypes []EventType
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			eventTypes = append(eventTypes, EventType(field))
+		}
+	}
+	return eventTypes
+}
+
+// parseAttributes parses a comma-separated list of key=value attributes.
+func parseAttributes(value string) ([]attribute.KeyValue, error) {
+	var attrs []attribute.KeyValue
+	for _, field := range strings.Split(value, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+
+		key, val, ok := strings.Cut(field, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("attribute %q is not in key=value format", field)
+		}
+
+		attrs = append(attrs, attribute.String(key, strings.TrimSpace(val)))
+	}
+	return attrs, nil
+}
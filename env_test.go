@@ -0,0 +1,108 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestEnvOptions(t *testing.T) {
+	t.Setenv(EnvEventTypes, "enforce, addPolicy")
+	t.Setenv(EnvExcludedEventTypes, "savePolicy")
+	t.Setenv(EnvSampleRate, "0.25")
+	t.Setenv(EnvSlowThreshold, "50ms")
+	t.Setenv(EnvMetricPrefix, "authz")
+	t.Setenv(EnvAttributes, "service=api, cluster=eu-1")
+
+	o := buildOptions(envOptions(), nil)
+
+	if len(o.eventTypes) != 2 || o.eventTypes[0] != EventEnforce || o.eventTypes[1] != EventAddPolicy {
+		t.Errorf("Unexpected event types: %v", o.eventTypes)
+	}
+
+	if len(o.excludedEventTypes) != 1 || o.excludedEventTypes[0] != EventSavePolicy {
+		t.Errorf("Unexpected excluded event types: %v", o.excludedEventTypes)
+	}
+
+	if sampler, ok := o.sampler.(*probabilitySampler); !ok || sampler.rate != 0.25 {
+		t.Errorf("Expected a probability sampler with rate 0.25, got %#v", o.sampler)
+	}
+
+	if o.slowThreshold != 50*time.Millisecond {
+		t.Errorf("Expected slow threshold of 50ms, got %v", o.slowThreshold)
+	}
+
+	if o.metricPrefix != "authz" {
+		t.Errorf("Expected metric prefix authz, got %s", o.metricPrefix)
+	}
+
+	expected := []attribute.KeyValue{
+		attribute.String("service", "api"),
+		attribute.String("cluster", "eu-1"),
+	}
+	if len(o.attributes) != len(expected) {
+		t.Fatalf("Expected %d attributes, got %v", len(expected), o.attributes)
+	}
+	for i, attr := range expected {
+		if o.attributes[i] != attr {
+			t.Errorf("Expected attribute %v, got %v", attr, o.attributes[i])
+		}
+	}
+}
+
+func TestEnvOptions_ExplicitOptionsWin(t *testing.T) {
+	t.Setenv(EnvEventTypes, "enforce")
+	t.Setenv(EnvMetricPrefix, "authz")
+
+	o := buildOptions(envOptions(), []Option{
+		WithEventTypes(EventAddPolicy),
+		WithMetricPrefix("custom"),
+	})
+
+	if len(o.eventTypes) != 1 || o.eventTypes[0] != EventAddPolicy {
+		t.Errorf("Expected explicit event types to win, got %v", o.eventTypes)
+	}
+
+	if o.metricPrefix != "custom" {
+		t.Errorf("Expected explicit metric prefix to win, got %s", o.metricPrefix)
+	}
+}
+
+func TestEnvOptions_InvalidValues(t *testing.T) {
+	t.Setenv(EnvSampleRate, "2")
+	t.Setenv(EnvSlowThreshold, "fast")
+	t.Setenv(EnvAttributes, "service")
+
+	o := buildOptions(envOptions(), nil)
+
+	if o.sampler != nil {
+		t.Errorf("Invalid sample rate should be ignored, got %#v", o.sampler)
+	}
+
+	if o.slowThreshold != 0 {
+		t.Errorf("Invalid slow threshold should be ignored, got %v", o.slowThreshold)
+	}
+
+	if o.attributes != nil {
+		t.Errorf("Invalid attributes should be ignored, got %v", o.attributes)
+	}
+
+	if o.metricPrefix != defaultMetricPrefix {
+		t.Errorf("Expected default metric prefix, got %s", o.metricPrefix)
+	}
+}
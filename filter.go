@@ -0,0 +1,70 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+// Filter reports whether an entry should be logged.
+//
+// A filter is evaluated twice: when the event begins, before the outcome is
+// known, and again when it ends, once EndTime and the outcome fields such as
+// Allowed, Error and Duration are set. EndTime is always zero when the event
+// begins, even if the entry is reused, so filters can use it to tell the two
+// evaluations apart.
+type Filter func(entry *LogEntry) bool
+
+// And returns a filter that accepts an entry only if all filters accept it.
+func And(filters ...Filter) Filter {
+	return func(entry *LogEntry) bool {
+		for _, filter := range filters {
+			if filter != nil && !filter(entry) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a filter that accepts an entry if any of the filters accepts it.
+func Or(filters ...Filter) Filter {
+	return func(entry *LogEntry) bool {
+		for _, filter := range filters {
+			if filter != nil && filter(entry) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a filter that accepts an entry if the filter rejects it. A
+// nil filter accepts every entry, so Not(nil) rejects every entry.
+func Not(filter Filter) Filter {
+	return func(entry *LogEntry) bool {
+		return filter != nil && !filter(entry)
+	}
+}
+
+// SetFilter configures a filter that entries must pass in addition to the
+// configured event types. A nil filter removes the filter.
+func (l *OpenTelemetryLogger) SetFilter(filter Filter) error {
+	l.update(func(s *settings) {
+		s.filter = filter
+	})
+	return nil
+}
+
+// accepts reports whether the entry passes the configured filter.
+func (s *settings) accepts(entry *LogEntry) bool {
+	return s.filter == nil || s.filter(entry)
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestFilterHelpers(t *testing.T) {
+	prodDomain := func(entry *LogEntry) bool {
+		return strings.HasPrefix(entry.Domain, "prod-")
+	}
+	denied := func(entry *LogEntry) bool {
+		return !entry.Allowed
+	}
+
+	testCases := []struct {
+		name     string
+		filter   Filter
+		entry    *LogEntry
+		expected bool
+	}{
+		{"And accepts", And(prodDomain, denied), &LogEntry{Domain: "prod-eu"}, true},
+		{"And rejects", And(prodDomain, denied), &LogEntry{Domain: "prod-eu", Allowed: true}, false},
+		{"And empty", And(), &LogEntry{}, true},
+		{"Or accepts", Or(prodDomain, denied), &LogEntry{Domain: "dev", Allowed: false}, true},
+		{"Or rejects", Or(prodDomain, denied), &LogEntry{Domain: "dev", Allowed: true}, false},
+		{"Or empty", Or(), &LogEntry{}, false},
+		{"Not", Not(prodDomain), &LogEntry{Domain: "dev"}, true},
+		{"Nested", And(prodDomain, Not(denied)), &LogEntry{Domain: "prod-us", Allowed: true}, true},
+		{"Nil filters ignored", And(nil, prodDomain), &LogEntry{Domain: "prod-us"}, true},
+		{"Not nil", Not(nil), &LogEntry{Domain: "prod-us"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter(tc.entry); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetFilter_OnBeforeEvent(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	err = logger.SetFilter(func(entry *LogEntry) bool {
+		return strings.HasPrefix(entry.Domain, "prod-")
+	})
+	if err != nil {
+		t.Errorf("SetFilter returned error: %v", err)
+	}
+
+	prodEntry := &LogEntry{EventType: EventEnforce, Domain: "prod-eu"}
+	logger.OnBeforeEvent(prodEntry)
+	if !prodEntry.IsActive {
+		t.Error("Entry matching the filter should be active")
+	}
+
+	devEntry := &LogEntry{EventType: EventEnforce, Domain: "dev"}
+	logger.OnBeforeEvent(devEntry)
+	if devEntry.IsActive {
+		t.Error("Entry not matching the filter should not be active")
+	}
+
+	logger.SetFilter(nil)
+	devEntry = &LogEntry{EventType: EventEnforce, Domain: "dev"}
+	logger.OnBeforeEvent(devEntry)
+	if !devEntry.IsActive {
+		t.Error("Entry should be active after removing the filter")
+	}
+}
+
+func TestSetFilter_OnAfterEvent(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	// Only denied requests are logged, which is only known after the event
+	logger.SetFilter(func(entry *LogEntry) bool {
+		return !entry.Allowed
+	})
+
+	callbackCalls := 0
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		callbackCalls++
+		return nil
+	})
+
+	allowedEntry := &LogEntry{EventType: EventEnforce, Subject: "alice"}
+	logger.OnBeforeEvent(allowedEntry)
+	allowedEntry.Allowed = true
+	logger.OnAfterEvent(allowedEntry)
+
+	deniedEntry := &LogEntry{EventType: EventEnforce, Subject: "bob"}
+	logger.OnBeforeEvent(deniedEntry)
+	deniedEntry.Allowed = false
+	logger.OnAfterEvent(deniedEntry)
+
+	if callbackCalls != 1 {
+		t.Errorf("Expected callback to be called once, got %d", callbackCalls)
+	}
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	total, ok := findMetric(rm, "casbin.enforce.total")
+	if !ok {
+		t.Fatal("Expected enforce metrics to be recorded")
+	}
+	sum := total.Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != 1 {
+		t.Fatalf("Expected 1 data point, got %d", len(sum.DataPoints))
+	}
+	allowed, _ := sum.DataPoints[0].Attributes.Value("allowed")
+	if allowed.AsString() != "false" || sum.DataPoints[0].Value != 1 {
+		t.Errorf("Expected a single denied request, got %+v", sum.DataPoints[0])
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// EventHandle tracks an event started with BeginEvent. It keeps the timing
+// and activation state of the event, so the entries passed to BeginEvent
+// and End are never modified and can be reused or shared.
+type EventHandle struct {
+	logger    *OpenTelemetryLogger
+	active    bool
+	startTime time.Time
+	ended     atomic.Bool
+}
+
+// BeginEvent is called before an event occurs and returns the handle used
+// to complete it. Unlike OnBeforeEvent, it does not modify the entry.
+func (l *OpenTelemetryLogger) BeginEvent(entry *LogEntry) (*EventHandle, error) {
+	c := *entry
+	c.EndTime = time.Time{}
+	startTime, active, err := l.begin(&c)
+	return &EventHandle{
+		logger:    l,
+		active:    active,
+		startTime: startTime,
+	}, err
+}
+
+// Active reports whether the event is logged. Inactive events are not
+// recorded when they end.
+func (h *EventHandle) Active() bool {
+	return h.active
+}
+
+// StartTime returns the start time of an active event.
+func (h *EventHandle) StartTime() time.Time {
+	return h.startTime
+}
+
+// End is called after the event completes with the entry describing its
+// outcome, and records metrics. The logger works on a copy of the entry, so
+// the entry itself is not modified; its IsActive and StartTime fields are
+// ignored. End returns an error if the event has already ended.
+func (h *EventHandle) End(entry *LogEntry) error {
+	if !h.active {
+		return nil
+	}
+
+	if !h.ended.CompareAndSwap(false, true) {
+		return errors.New("event has already ended")
+	}
+
+	c := *entry
+	c.IsActive = true
+	c.StartTime = h.startTime
+	c.EndTime = time.Time{}
+	return h.logger.finish(&c)
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestBeginEvent(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var delivered []LogEntry
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		delivered = append(delivered, *entry)
+		return nil
+	})
+
+	entry := &LogEntry{EventType: EventEnforce, Subject: "alice", Allowed: true}
+	original := *entry
+
+	handle, err := logger.BeginEvent(entry)
+	if err != nil {
+		t.Fatalf("BeginEvent returned error: %v", err)
+	}
+	if !handle.Active() {
+		t.Fatal("Expected the event to be active")
+	}
+
+	if err := handle.End(entry); err != nil {
+		t.Errorf("End returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(*entry, original) {
+		t.Errorf("Expected the entry not to be modified, got %+v", *entry)
+	}
+
+	if len(delivered) != 1 {
+		t.Fatalf("Expected 1 delivered entry, got %d", len(delivered))
+	}
+	if !delivered[0].StartTime.Equal(handle.StartTime()) || delivered[0].EndTime.IsZero() {
+		t.Errorf("Expected the delivered entry to carry the timing of the handle, got %+v", delivered[0])
+	}
+
+	if err := handle.End(entry); err == nil {
+		t.Error("Expected an error when ending an event twice")
+	}
+	if len(delivered) != 1 {
+		t.Errorf("Expected the event to be delivered once, got %d", len(delivered))
+	}
+}
+
+func TestBeginEvent_Inactive(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithEventTypes(EventAddPolicy))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	called := false
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		called = true
+		return nil
+	})
+
+	entry := &LogEntry{EventType: EventEnforce}
+	handle, _ := logger.BeginEvent(entry)
+	if handle.Active() {
+		t.Error("Expected the event to be inactive")
+	}
+	if err := handle.End(entry); err != nil {
+		t.Errorf("End returned error: %v", err)
+	}
+	if called {
+		t.Error("Expected an inactive event not to be delivered")
+	}
+}
+
+func TestBeginEvent_SharedEntry(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	// A single entry can be shared by concurrent events
+	entry := &LogEntry{EventType: EventEnforce, Subject: "alice", Allowed: true}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				handle, _ := logger.BeginEvent(entry)
+				handle.End(entry)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if total := collectSum(t, reader, "casbin.enforce.total"); total != 800 {
+		t.Errorf("Expected 800 enforce requests, got %d", total)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"errors"
+	"time"
+)
+
+// EventInfo holds the fields common to all typed events.
+type EventInfo struct {
+	// StartTime is the time at which the event started.
+	StartTime time.Time
+	// Duration is the duration of the event.
+	Duration time.Duration
+	// Slow indicates that the event exceeded the configured slow threshold.
+	Slow bool
+	// Error contains any error that occurred during the event.
+	Error error
+}
+
+// EnforceEvent is a completed enforce request.
+type EnforceEvent struct {
+	EventInfo
+	Subject string
+	Object  string
+	Action  string
+	Domain  string
+	Allowed bool
+	// Explanations contains the policy rules that explain the decision, as
+	// returned by EnforceEx.
+	Explanations [][]string
+}
+
+// BatchEnforceEvent is a completed batch enforce request.
+type BatchEnforceEvent struct {
+	EventInfo
+	// Requests contains the individual requests with their decisions.
+	Requests []EnforceRequest
+}
+
+// PolicyEvent is a completed policy operation.
+type PolicyEvent struct {
+	EventInfo
+	// Operation is EventAddPolicy, EventRemovePolicy, EventLoadPolicy or
+	// EventSavePolicy.
+	Operation EventType
+	Domain    string
+	// Rules contains the policy rules involved in the operation.
+	Rules [][]string
+	// RuleCount is the number of rules affected by the operation.
+	RuleCount int
+}
+
+// RoleLinksEvent is a completed role-link rebuild.
+type RoleLinksEvent struct {
+	EventInfo
+	// LinkCount is the number of role links built.
+	LinkCount int
+}
+
+// WatcherEvent is a policy update published or received through a watcher.
+type WatcherEvent struct {
+	EventInfo
+	// Operation is EventWatcherPublish or EventWatcherReceive.
+	Operation EventType
+	// NodeID is the ID of the node that published the update.
+	NodeID string
+	// PublishTime is the time at which the update was published.
+	PublishTime time.Time
+}
+
+// OnEnforce adds a callback for enforce events, as AddLogCallback does.
+func (l *OpenTelemetryLogger) OnEnforce(handler func(event EnforceEvent) error) (CallbackID, error) {
+	if handler == nil {
+		return 0, errors.New("event handler must not be nil")
+	}
+
+	return l.AddLogCallback(func(entry *LogEntry) error {
+		if entry.EventType != EventEnforce {
+			return nil
+		}
+		return handler(EnforceEvent{
+			EventInfo:    newEventInfo(entry),
+			Subject:      entry.Subject,
+			Object:       entry.Object,
+			Action:       entry.Action,
+			Domain:       entry.Domain,
+			Allowed:      entry.Allowed,
+			Explanations: entry.Explanations,
+		})
+	})
+}
+
+// OnBatchEnforce adds a callback for batch enforce events, as
+// AddLogCallback does.
+func (l *OpenTelemetryLogger) OnBatchEnforce(handler func(event BatchEnforceEvent) error) (CallbackID, error) {
+	if handler == nil {
+		return 0, errors.New("event handler must not be nil")
+	}
+
+	return l.AddLogCallback(func(entry *LogEntry) error {
+		if entry.EventType != EventBatchEnforce {
+			return nil
+		}
+		return handler(BatchEnforceEvent{
+			EventInfo: newEventInfo(entry),
+			Requests:  entry.Requests,
+		})
+	})
+}
+
+// OnPolicyChange adds a callback for policy operations, as AddLogCallback
+// does.
+func (l *OpenTelemetryLogger) OnPolicyChange(handler func(event PolicyEvent) error) (CallbackID, error) {
+	if handler == nil {
+		return 0, errors.New("event handler must not be nil")
+	}
+
+	return l.AddLogCallback(func(entry *LogEntry) error {
+		switch entry.EventType {
+		case EventAddPolicy, EventRemovePolicy, EventLoadPolicy, EventSavePolicy:
+		default:
+			return nil
+		}
+		return handler(PolicyEvent{
+			EventInfo: newEventInfo(entry),
+			Operation: entry.EventType,
+			Domain:    entry.Domain,
+			Rules:     entry.Rules,
+			RuleCount: entry.RuleCount,
+		})
+	})
+}
+
+// OnRoleLinksBuild adds a callback for role-link rebuilds, as
+// AddLogCallback does.
+func (l *OpenTelemetryLogger) OnRoleLinksBuild(handler func(event RoleLinksEvent) error) (CallbackID, error) {
+	if handler == nil {
+		return 0, errors.New("event handler must not be nil")
+	}
+
+	return l.AddLogCallback(func(entry *LogEntry) error {
+		if entry.EventType != EventBuildRoleLinks {
+			return nil
+		}
+		return handler(RoleLinksEvent{
+			EventInfo: newEventInfo(entry),
+			LinkCount: entry.LinkCount,
+		})
+	})
+}
+
+// OnWatcherUpdate adds a callback for watcher events, as AddLogCallback
+// does.
+func (l *OpenTelemetryLogger) OnWatcherUpdate(handler func(event WatcherEvent) error) (CallbackID, error) {
+	if handler == nil {
+		return 0, errors.New("event handler must not be nil")
+	}
+
+	return l.AddLogCallback(func(entry *LogEntry) error {
+		if entry.EventType != EventWatcherPublish && entry.EventType != EventWatcherReceive {
+			return nil
+		}
+		return handler(WatcherEvent{
+			EventInfo:   newEventInfo(entry),
+			Operation:   entry.EventType,
+			NodeID:      entry.NodeID,
+			PublishTime: entry.PublishTime,
+		})
+	})
+}
+
+// newEventInfo returns the common fields of the entry.
+func newEventInfo(entry *LogEntry) EventInfo {
+	return EventInfo{
+		StartTime: entry.StartTime,
+		Duration:  entry.Duration,
+		Slow:      entry.Slow,
+		Error:     entry.Error,
+	}
+}
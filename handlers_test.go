@@ -0,0 +1,147 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestTypedHandlers(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var enforceEvents []EnforceEvent
+	var batchEvents []BatchEnforceEvent
+	var policyEvents []PolicyEvent
+	var roleLinksEvents []RoleLinksEvent
+	var watcherEvents []WatcherEvent
+
+	logger.OnEnforce(func(event EnforceEvent) error {
+		enforceEvents = append(enforceEvents, event)
+		return nil
+	})
+	logger.OnBatchEnforce(func(event BatchEnforceEvent) error {
+		batchEvents = append(batchEvents, event)
+		return nil
+	})
+	logger.OnPolicyChange(func(event PolicyEvent) error {
+		policyEvents = append(policyEvents, event)
+		return nil
+	})
+	logger.OnRoleLinksBuild(func(event RoleLinksEvent) error {
+		roleLinksEvents = append(roleLinksEvents, event)
+		return nil
+	})
+	logger.OnWatcherUpdate(func(event WatcherEvent) error {
+		watcherEvents = append(watcherEvents, event)
+		return nil
+	})
+
+	publishTime := time.Now()
+	policyErr := errors.New("adapter failure")
+	entries := []*LogEntry{
+		{EventType: EventEnforce, Subject: "alice", Object: "data1", Action: "read", Domain: "domain1", Allowed: true},
+		{EventType: EventBatchEnforce, Requests: []EnforceRequest{{Subject: "bob", Allowed: false}}},
+		{EventType: EventAddPolicy, Domain: "domain1", Rules: [][]string{{"alice", "data1", "read"}}, RuleCount: 1},
+		{EventType: EventSavePolicy, Error: policyErr},
+		{EventType: EventBuildRoleLinks, LinkCount: 3},
+		{EventType: EventWatcherReceive, NodeID: "node-1", PublishTime: publishTime},
+	}
+	for _, entry := range entries {
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	if len(enforceEvents) != 1 {
+		t.Fatalf("Expected 1 enforce event, got %d", len(enforceEvents))
+	}
+	enforce := enforceEvents[0]
+	if enforce.Subject != "alice" || enforce.Object != "data1" || enforce.Action != "read" || enforce.Domain != "domain1" || !enforce.Allowed {
+		t.Errorf("Unexpected enforce event: %+v", enforce)
+	}
+	if enforce.StartTime.IsZero() {
+		t.Error("Expected the enforce event to have a start time")
+	}
+
+	if len(batchEvents) != 1 || len(batchEvents[0].Requests) != 1 || batchEvents[0].Requests[0].Subject != "bob" {
+		t.Errorf("Unexpected batch enforce events: %+v", batchEvents)
+	}
+
+	if len(policyEvents) != 2 {
+		t.Fatalf("Expected 2 policy events, got %d", len(policyEvents))
+	}
+	if policyEvents[0].Operation != EventAddPolicy || policyEvents[0].RuleCount != 1 || policyEvents[0].Domain != "domain1" {
+		t.Errorf("Unexpected policy event: %+v", policyEvents[0])
+	}
+	if policyEvents[1].Operation != EventSavePolicy || policyEvents[1].Error != policyErr {
+		t.Errorf("Unexpected policy event: %+v", policyEvents[1])
+	}
+
+	if len(roleLinksEvents) != 1 || roleLinksEvents[0].LinkCount != 3 {
+		t.Errorf("Unexpected role links events: %+v", roleLinksEvents)
+	}
+
+	if len(watcherEvents) != 1 || watcherEvents[0].NodeID != "node-1" || !watcherEvents[0].PublishTime.Equal(publishTime) {
+		t.Errorf("Unexpected watcher events: %+v", watcherEvents)
+	}
+}
+
+func TestTypedHandlers_Remove(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	if _, err := logger.OnEnforce(nil); err == nil {
+		t.Error("Expected an error for a nil handler")
+	}
+
+	calls := 0
+	id, err := logger.OnEnforce(func(event EnforceEvent) error {
+		calls++
+		return errors.New("handler failure")
+	})
+	if err != nil {
+		t.Fatalf("OnEnforce returned error: %v", err)
+	}
+
+	entry := &LogEntry{EventType: EventEnforce}
+	logger.OnBeforeEvent(entry)
+	if err := logger.OnAfterEvent(entry); err == nil {
+		t.Error("Expected the handler error to be returned")
+	}
+
+	logger.RemoveLogCallback(id)
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	if calls != 1 {
+		t.Errorf("Expected the removed handler not to be called, got %d calls", calls)
+	}
+}
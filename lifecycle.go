@@ -0,0 +1,136 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// ErrLoggerShutdown is returned for events and operations on a logger that
+// has been shut down.
+var ErrLoggerShutdown = errors.New("logger is shut down")
+
+// Flusher is implemented by sinks that buffer entries.
+type Flusher interface {
+	// Flush writes the buffered entries.
+	Flush() error
+}
+
+// ForceFlush delivers the entries waiting in the asynchronous delivery
+// queue and flushes the sinks implementing Flusher. It returns when the
+// entries have been delivered or ctx is done.
+func (l *OpenTelemetryLogger) ForceFlush(ctx context.Context) error {
+	if l.isShutdown.Load() {
+		return ErrLoggerShutdown
+	}
+
+	if l.dispatcher != nil {
+		if err := l.dispatcher.flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, sink := range l.settings.Load().allSinks() {
+		if flusher, ok := sink.(Flusher); ok {
+			if err := flusher.Flush(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown stops the logger. It stops the configuration watchers, delivers
+// the entries waiting in the asynchronous delivery queue, unregisters the
+// observable metrics, closes the sinks, including those of domain
+// overrides and those opened from a configuration, and closes the channels of subscriptions. Events are rejected
+// with ErrLoggerShutdown from then on, so Shutdown should be called once
+// enforcement has stopped. If ctx is done before the queue is drained, the
+// remaining entries are delivered in the background while the sinks are
+// closed. Shutdown may only be called once.
+func (l *OpenTelemetryLogger) Shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	if l.isShutdown.Load() {
+		l.mu.Unlock()
+		return ErrLoggerShutdown
+	}
+	l.isShutdown.Store(true)
+	l.mu.Unlock()
+
+	close(l.done)
+	l.watchers.Wait()
+
+	var errs []error
+	if l.dispatcher != nil {
+		if err := l.dispatcher.close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if l.queueRegistration != nil {
+		if err := l.queueRegistration.Unregister(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Domain sinks opened from the configuration are closed even if a
+	// reload removed their pattern
+	sinks := l.settings.Load().allSinks()
+	for _, opened := range l.domainSinks {
+		for _, sink := range opened {
+			if !containsSink(sinks, sink) {
+				sinks = append(sinks, sink)
+			}
+		}
+	}
+	if err := closeSinks(sinks); err != nil {
+		errs = append(errs, err)
+	}
+
+	l.closeSubscriptions()
+
+	return errors.Join(errs...)
+}
+
+// allSinks returns the global sinks and the sinks of the domain overrides,
+// each sink once.
+func (s *settings) allSinks() []Sink {
+	sinks := append([]Sink(nil), s.sinks...)
+	for _, override := range s.domains {
+		for _, sink := range override.sinks {
+			if !containsSink(sinks, sink) {
+				sinks = append(sinks, sink)
+			}
+		}
+	}
+	return sinks
+}
+
+// containsSink reports whether the sink is in sinks, comparing only sinks
+// whose dynamic type is comparable.
+func containsSink(sinks []Sink, sink Sink) bool {
+	if !reflect.TypeOf(sink).Comparable() {
+		return false
+	}
+	for _, s := range sinks {
+		if reflect.TypeOf(s) == reflect.TypeOf(sink) && s == sink {
+			return true
+		}
+	}
+	return false
+}
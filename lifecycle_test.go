@@ -0,0 +1,279 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// recordingSink is a sink counting the calls to its methods.
+type recordingSink struct {
+	mu      sync.Mutex
+	writes  int
+	flushes int
+	closes  int
+}
+
+func (s *recordingSink) Write(entry *LogEntry) error {
+	time.Sleep(100 * time.Microsecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes++
+	return nil
+}
+
+func (s *recordingSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flushes++
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closes++
+	return nil
+}
+
+func (s *recordingSink) counts() (writes, flushes, closes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writes, s.flushes, s.closes
+}
+
+func TestShutdown(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	sink := &recordingSink{}
+	domainSink := &recordingSink{}
+	logger, err := NewOpenTelemetryLogger(meter,
+		WithAsyncDelivery(AsyncConfig{QueueSize: 100}),
+		WithSinks(sink),
+		WithDomainOverride("audit", DomainOverride{Sinks: []Sink{domainSink, sink}}),
+	)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	for i := 0; i < 50; i++ {
+		entry := &LogEntry{EventType: EventEnforce}
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+
+	writes, _, closes := sink.counts()
+	if writes != 50 {
+		t.Errorf("Expected the queued entries to be delivered, got %d of 50", writes)
+	}
+	if closes != 1 {
+		t.Errorf("Expected the sink to be closed once, got %d", closes)
+	}
+	if _, _, closes := domainSink.counts(); closes != 1 {
+		t.Errorf("Expected the domain sink to be closed once, got %d", closes)
+	}
+
+	entry := &LogEntry{EventType: EventEnforce}
+	if err := logger.OnBeforeEvent(entry); !errors.Is(err, ErrLoggerShutdown) {
+		t.Errorf("Expected ErrLoggerShutdown, got %v", err)
+	}
+	if entry.IsActive {
+		t.Error("Expected events to be rejected after shutdown")
+	}
+
+	if err := logger.Shutdown(context.Background()); !errors.Is(err, ErrLoggerShutdown) {
+		t.Errorf("Expected ErrLoggerShutdown for a second shutdown, got %v", err)
+	}
+	if err := logger.ForceFlush(context.Background()); !errors.Is(err, ErrLoggerShutdown) {
+		t.Errorf("Expected ErrLoggerShutdown for a flush after shutdown, got %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+	if _, ok := findMetric(rm, "casbin.logger.queue.depth"); ok {
+		t.Error("Expected the queue depth to no longer be observed")
+	}
+}
+
+func TestShutdown_BlockedProducer(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithAsyncDelivery(AsyncConfig{
+		QueueSize: 1,
+		Workers:   1,
+		Overflow:  OverflowBlock,
+	}))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	log := func() {
+		entry := &LogEntry{EventType: EventEnforce}
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	// The worker is stuck on the first entry and the second fills the
+	// queue, so the third event blocks
+	log()
+	<-started
+	log()
+	produced := make(chan struct{})
+	go func() {
+		defer close(produced)
+		log()
+	}()
+	waitFor(t, func() bool { return logger.dispatcher.pending.Load() == 3 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- logger.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-shutdown:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected the shutdown to time out, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown did not respect its deadline")
+	}
+
+	select {
+	case <-produced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the blocked event to be released by the shutdown")
+	}
+}
+
+func TestShutdown_StopsWatchers(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{}`)
+
+	if err := logger.WatchConfigFile(context.Background(), path, time.Millisecond); err != nil {
+		t.Fatalf("WatchConfigFile returned error: %v", err)
+	}
+
+	// Shutdown waits for the watcher to stop
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+
+	if err := logger.WatchConfigFile(context.Background(), path, time.Millisecond); !errors.Is(err, ErrLoggerShutdown) {
+		t.Errorf("Expected ErrLoggerShutdown, got %v", err)
+	}
+}
+
+func TestForceFlush(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	sink := &recordingSink{}
+	logger, err := NewOpenTelemetryLogger(meter,
+		WithAsyncDelivery(AsyncConfig{QueueSize: 100, Workers: 2}),
+		WithSinks(sink),
+	)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		entry := &LogEntry{EventType: EventEnforce}
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	if err := logger.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush returned error: %v", err)
+	}
+
+	writes, flushes, closes := sink.counts()
+	if writes != 20 || flushes != 1 || closes != 0 {
+		t.Errorf("Expected 20 writes, 1 flush and no close, got %d, %d and %d", writes, flushes, closes)
+	}
+}
+
+func TestForceFlush_Timeout(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithAsyncDelivery(AsyncConfig{}))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	release := make(chan struct{})
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		<-release
+		return nil
+	})
+
+	entry := &LogEntry{EventType: EventEnforce}
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := logger.ForceFlush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the flush to time out, got %v", err)
+	}
+
+	close(release)
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown returned error: %v", err)
+	}
+}
@@ -16,6 +16,9 @@ package opentelemetrylogger
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -24,38 +27,110 @@ import (
 
 // OpenTelemetryLogger is a logger that exports metrics to OpenTelemetry.
 type OpenTelemetryLogger struct {
-	enabledEventTypes map[EventType]bool
-	callback          func(entry *LogEntry) error
+	// settings holds the runtime configuration, replaced atomically by
+	// setters. mu serializes the setters.
+	settings atomic.Pointer[settings]
+	mu       sync.Mutex
 
 	// OpenTelemetry metrics
 	enforceDuration   metric.Float64Histogram
 	enforceTotal      metric.Int64Counter
+	batchDuration     metric.Float64Histogram
+	batchRequestTotal metric.Int64Counter
 	policyOpsTotal    metric.Int64Counter
 	policyOpsDuration metric.Float64Histogram
 	policyRulesCount  metric.Int64Gauge
+	roleLinksDuration metric.Float64Histogram
+	roleLinksCount    metric.Int64Gauge
+	watcherUpdates    metric.Int64Counter
+	watcherLatency    metric.Float64Histogram
+	eventsTotal       metric.Int64Counter
+	eventsDuration    metric.Float64Histogram
+	configVersion     metric.Int64Gauge
+	configErrors      metric.Int64Counter
+	callbackFailures  metric.Int64Counter
+	invalidEntries    metric.Int64Counter
+	queueDepth        metric.Int64ObservableGauge
+	queueDropped      metric.Int64Counter
+
+	// dispatcher delivers entries asynchronously, or is nil if entries are
+	// delivered by OnAfterEvent.
+	dispatcher        *dispatcher
+	queueRegistration metric.Registration
+
+	// version is the number of configurations applied to the logger.
+	version atomic.Int64
+
+	// lastCallbackID is the ID of the last added callback, guarded by mu.
+	lastCallbackID CallbackID
+
+	// isShutdown is set by Shutdown, which closes done to stop the
+	// configuration watchers.
+	isShutdown atomic.Bool
+	done       chan struct{}
+	watchers   sync.WaitGroup
+
+	// subscriptions are the subscriptions closed on shutdown, guarded by mu.
+	subscriptions map[*subscription]bool
+
+	// attributes are added to every measurement of the built-in metrics.
+	attributes []attribute.KeyValue
+
+	// clock provides the start and end times of events.
+	clock Clock
+
+	// envOptions and explicitOptions are the options read from the
+	// environment and passed explicitly when the logger was created. They
+	// are applied again around reloaded configurations to keep precedence.
+	envOptions      []Option
+	explicitOptions []Option
+
+	// domainSinks are the sinks of domain overrides opened from a
+	// configuration, by pattern. They are restored when a reloaded
+	// configuration adds a pattern back and closed on shutdown, even if
+	// their pattern was removed.
+	domainSinks map[string][]Sink
 
 	ctx context.Context
+	// invalidDurationCtx is ctx marked for recording entries with invalid
+	// timing.
+	invalidDurationCtx context.Context
 }
 
 // NewOpenTelemetryLogger creates a new OpenTelemetryLogger with the provided meter.
-func NewOpenTelemetryLogger(meter metric.Meter) (*OpenTelemetryLogger, error) {
-	return NewOpenTelemetryLoggerWithContext(context.Background(), meter)
+func NewOpenTelemetryLogger(meter metric.Meter, opts ...Option) (*OpenTelemetryLogger, error) {
+	return NewOpenTelemetryLoggerWithContext(context.Background(), meter, opts...)
 }
 
 // NewOpenTelemetryLoggerWithContext creates a new OpenTelemetryLogger with a custom context and meter.
-func NewOpenTelemetryLoggerWithContext(ctx context.Context, meter metric.Meter) (*OpenTelemetryLogger, error) {
+//
+// The logger is configured from the CASBIN_OTEL_* environment variables
+// first, and then from the provided options, which take precedence.
+func NewOpenTelemetryLoggerWithContext(ctx context.Context, meter metric.Meter, opts ...Option) (*OpenTelemetryLogger, error) {
+	env := envOptions()
+	o := buildOptions(env, opts)
+
 	logger := &OpenTelemetryLogger{
-		enabledEventTypes: make(map[EventType]bool),
-		ctx:               ctx,
+		attributes: o.attributes,
+		clock:      o.clock,
+
+		envOptions:      env,
+		explicitOptions: opts,
+		ctx:             ctx,
+		done:            make(chan struct{}),
+
+		invalidDurationCtx: context.WithValue(ctx, invalidDurationKey{}, true),
+
+		subscriptions: make(map[*subscription]bool),
 	}
+	logger.settings.Store(o.settings())
 
 	var err error
 
 	// Create enforce duration histogram
 	logger.enforceDuration, err = meter.Float64Histogram(
-		"casbin.enforce.duration",
-		metric.WithDescription("Duration of enforce requests in seconds"),
-		metric.WithUnit("s"),
+		o.metricPrefix+".enforce.duration",
+		o.durationHistogramOptions("Duration of enforce requests in seconds")...,
 	)
 	if err != nil {
 		return nil, err
@@ -63,16 +138,34 @@ func NewOpenTelemetryLoggerWithContext(ctx context.Context, meter metric.Meter)
 
 	// Create enforce total counter
 	logger.enforceTotal, err = meter.Int64Counter(
-		"casbin.enforce.total",
+		o.metricPrefix+".enforce.total",
 		metric.WithDescription("Total number of enforce requests"),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// Create batch enforce duration histogram
+	logger.batchDuration, err = meter.Float64Histogram(
+		o.metricPrefix+".enforce.batch.duration",
+		o.durationHistogramOptions("Duration of batch enforce requests in seconds")...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create batch enforce requests counter
+	logger.batchRequestTotal, err = meter.Int64Counter(
+		o.metricPrefix+".enforce.batch.requests.total",
+		metric.WithDescription("Total number of requests evaluated in batch enforce calls"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create policy operations total counter
 	logger.policyOpsTotal, err = meter.Int64Counter(
-		"casbin.policy.operations.total",
+		o.metricPrefix+".policy.operations.total",
 		metric.WithDescription("Total number of policy operations"),
 	)
 	if err != nil {
@@ -81,9 +174,8 @@ func NewOpenTelemetryLoggerWithContext(ctx context.Context, meter metric.Meter)
 
 	// Create policy operations duration histogram
 	logger.policyOpsDuration, err = meter.Float64Histogram(
-		"casbin.policy.operations.duration",
-		metric.WithDescription("Duration of policy operations in seconds"),
-		metric.WithUnit("s"),
+		o.metricPrefix+".policy.operations.duration",
+		o.durationHistogramOptions("Duration of policy operations in seconds")...,
 	)
 	if err != nil {
 		return nil, err
@@ -91,35 +183,163 @@ func NewOpenTelemetryLoggerWithContext(ctx context.Context, meter metric.Meter)
 
 	// Create policy rules count gauge
 	logger.policyRulesCount, err = meter.Int64Gauge(
-		"casbin.policy.rules.count",
+		o.metricPrefix+".policy.rules.count",
 		metric.WithDescription("Number of policy rules affected by operations"),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// Create role links build duration histogram
+	logger.roleLinksDuration, err = meter.Float64Histogram(
+		o.metricPrefix+".role_links.build.duration",
+		o.durationHistogramOptions("Duration of role link builds in seconds")...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create role links count gauge
+	logger.roleLinksCount, err = meter.Int64Gauge(
+		o.metricPrefix+".role_links.count",
+		metric.WithDescription("Number of role links built by role link builds"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create watcher updates counter
+	logger.watcherUpdates, err = meter.Int64Counter(
+		o.metricPrefix+".watcher.updates.total",
+		metric.WithDescription("Total number of policy updates published and received through the watcher"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create watcher propagation latency histogram
+	logger.watcherLatency, err = meter.Float64Histogram(
+		o.metricPrefix+".watcher.propagation.latency",
+		o.durationHistogramOptions("Latency between publishing and receiving policy updates in seconds")...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create generic events total counter
+	logger.eventsTotal, err = meter.Int64Counter(
+		o.metricPrefix+".events.total",
+		metric.WithDescription("Total number of events of any type"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create generic events duration histogram
+	logger.eventsDuration, err = meter.Float64Histogram(
+		o.metricPrefix+".events.duration",
+		o.durationHistogramOptions("Duration of events of any type in seconds")...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create config version gauge
+	logger.configVersion, err = meter.Int64Gauge(
+		o.metricPrefix+".logger.config.version",
+		metric.WithDescription("Version of the configuration applied to the logger"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create config reload errors counter
+	logger.configErrors, err = meter.Int64Counter(
+		o.metricPrefix+".logger.config.reload.errors",
+		metric.WithDescription("Total number of failed configuration reloads"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create callback failures counter
+	logger.callbackFailures, err = meter.Int64Counter(
+		o.metricPrefix+".logger.callback.failures",
+		metric.WithDescription("Total number of callbacks and sinks that panicked or timed out"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create invalid entries counter
+	logger.invalidEntries, err = meter.Int64Counter(
+		o.metricPrefix+".logger.invalid_entries",
+		metric.WithDescription("Total number of entries whose duration was not recorded because of invalid timing"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create queue depth gauge
+	logger.queueDepth, err = meter.Int64ObservableGauge(
+		o.metricPrefix+".logger.queue.depth",
+		metric.WithDescription("Number of entries waiting for asynchronous delivery"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create queue dropped counter
+	logger.queueDropped, err = meter.Int64Counter(
+		o.metricPrefix+".logger.queue.dropped",
+		metric.WithDescription("Total number of entries dropped because the delivery queue was full"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.registerDefaultRecorders()
+
+	if o.async != nil {
+		if err := logger.startDispatcher(meter, *o.async); err != nil {
+			return nil, err
+		}
+	}
+
 	return logger, nil
 }
 
 // SetEventTypes configures which event types should be logged.
 func (l *OpenTelemetryLogger) SetEventTypes(eventTypes []EventType) error {
-	l.enabledEventTypes = make(map[EventType]bool)
-	for _, eventType := range eventTypes {
-		l.enabledEventTypes[eventType] = true
-	}
+	l.update(func(s *settings) {
+		s.enabledEventTypes = eventTypeSet(eventTypes)
+	})
 	return nil
 }
 
-// OnBeforeEvent is called before an event occurs.
+// SetExcludedEventTypes configures which event types should never be logged.
+// Excluded event types take precedence over the event types configured
+// with SetEventTypes, so excluding types while leaving the enabled list
+// empty logs everything except them, including event types added later.
+func (l *OpenTelemetryLogger) SetExcludedEventTypes(eventTypes []EventType) error {
+	l.update(func(s *settings) {
+		s.excludedEventTypes = eventTypeSet(eventTypes)
+	})
+	return nil
+}
+
+// OnBeforeEvent is called before an event occurs. It sets IsActive and
+// StartTime on the entry, which must be passed to OnAfterEvent once the
+// event completes. BeginEvent does the same without modifying the entry.
 func (l *OpenTelemetryLogger) OnBeforeEvent(entry *LogEntry) error {
-	if len(l.enabledEventTypes) > 0 && !l.enabledEventTypes[entry.EventType] {
-		entry.IsActive = false
-		return nil
+	// A reused entry still carries the end time of its previous event
+	entry.EndTime = time.Time{}
+	startTime, active, err := l.begin(entry)
+	entry.IsActive = active
+	if active {
+		entry.StartTime = startTime
 	}
-
-	entry.IsActive = true
-	entry.StartTime = time.Now()
-	return nil
+	return err
 }
 
 // OnAfterEvent is called after an event completes and records metrics.
@@ -127,55 +347,290 @@ func (l *OpenTelemetryLogger) OnAfterEvent(entry *LogEntry) error {
 	if !entry.IsActive {
 		return nil
 	}
+	return l.finish(entry)
+}
+
+// begin reports whether the event should be logged and returns its start
+// time if it should.
+func (l *OpenTelemetryLogger) begin(entry *LogEntry) (time.Time, bool, error) {
+	if l.isShutdown.Load() {
+		return time.Time{}, false, ErrLoggerShutdown
+	}
+
+	s := l.settings.Load()
 
-	entry.EndTime = time.Now()
+	if !s.eventTypeEnabled(entry.EventType, entry.Domain) {
+		return time.Time{}, false, nil
+	}
+
+	if !s.accepts(entry) {
+		return time.Time{}, false, nil
+	}
+
+	return l.clock.Now(), true, nil
+}
+
+// finish completes an active entry, records its metrics and delivers it.
+func (l *OpenTelemetryLogger) finish(entry *LogEntry) error {
+	if l.isShutdown.Load() {
+		return ErrLoggerShutdown
+	}
+
+	s := l.settings.Load()
+
+	entry.EndTime = l.clock.Now()
 	entry.Duration = entry.EndTime.Sub(entry.StartTime)
+	// A reused entry may still be tagged as slow from its previous event
+	entry.Slow = false
+
+	// Entries with a bogus duration are still delivered, but with a zero
+	// duration, so they do not skew the metrics
+	reason := invalidTiming(entry)
+	if reason != "" {
+		entry.Duration = 0
+	}
 
-	// Record metrics based on event type
-	switch entry.EventType {
-	case EventEnforce:
-		l.recordEnforceMetrics(entry)
-	case EventAddPolicy, EventRemovePolicy, EventLoadPolicy, EventSavePolicy:
-		l.recordPolicyMetrics(entry)
+	// Check the filter again now that the outcome is known
+	if !s.accepts(entry) {
+		return nil
 	}
 
-	// Call custom callback if set
-	if l.callback != nil {
-		return l.callback(entry)
+	// Entries with invalid timing are still counted, but their durations
+	// are not recorded
+	ctx := l.ctx
+	if reason != "" {
+		l.invalidEntries.Add(l.ctx, 1, l.withDomainAttributes(entry.Domain,
+			attribute.String("event_type", string(entry.EventType)),
+			attribute.String("reason", reason),
+		))
+		ctx = l.invalidDurationCtx
+	}
+
+	// Record generic metrics for every event, including unknown types
+	l.recordEventMetrics(ctx, entry)
+
+	// Record metrics with the recorder registered for the event type
+	if recorder, ok := s.recorders[entry.EventType]; ok {
+		recorder.Record(ctx, entry)
+	}
+
+	// Deliver the entry to the callback and sinks if it is selected
+	if s.hasReceivers(entry.Domain) && s.selected(entry) {
+		if l.dispatcher != nil {
+			l.enqueue(s, entry)
+			return nil
+		}
+		return l.deliver(s, entry)
 	}
 
 	return nil
 }
 
-// SetLogCallback sets a custom callback function for log entries.
+// invalidTiming returns the reason why the timing of a completed entry is
+// invalid, or an empty string if it is valid. A missing start time, as when
+// OnAfterEvent is called without OnBeforeEvent, would otherwise be recorded
+// as a duration of centuries.
+func invalidTiming(entry *LogEntry) string {
+	switch {
+	case entry.StartTime.IsZero():
+		return "missing_start_time"
+	case entry.Duration < 0:
+		return "negative_duration"
+	default:
+		return ""
+	}
+}
+
+// SetLogCallback sets a custom callback function for log entries. It is
+// called before the callbacks added with AddLogCallback, and a nil callback
+// removes it.
 func (l *OpenTelemetryLogger) SetLogCallback(callback func(entry *LogEntry) error) error {
-	l.callback = callback
+	l.update(func(s *settings) {
+		s.callback = callback
+	})
 	return nil
 }
 
-// recordEnforceMetrics records metrics for enforce events.
-func (l *OpenTelemetryLogger) recordEnforceMetrics(entry *LogEntry) {
-	domain := entry.Domain
+// hasReceivers reports whether a callback, a subscription or a sink for the
+// domain is configured.
+func (s *settings) hasReceivers(domain string) bool {
+	return s.callback != nil || len(s.callbacks) > 0 || len(s.subscriptions) > 0 ||
+		len(s.sinksFor(domain)) > 0
+}
+
+// deliver passes the entry to the subscriptions, the callbacks and the
+// sinks, redacting it first if redaction is configured. The sinks are chosen
+// by the domain before it is redacted.
+func (l *OpenTelemetryLogger) deliver(s *settings, entry *LogEntry) error {
+	sinks := s.sinksFor(entry.Domain)
+	entry = s.redact(entry)
+	s.publish(entry)
+	return l.deliverTo(s, entry, sinks)
+}
+
+// joinErrors returns nil for no errors, the error itself for a single error,
+// and the errors joined otherwise.
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errors.Join(errs...)
+	}
+}
+
+// eventTypeEnabled reports whether the event type should be logged for the
+// domain.
+func (s *settings) eventTypeEnabled(eventType EventType, domain string) bool {
+	if override := s.domainOverride(domain); override != nil && override.eventTypes != nil {
+		return override.eventTypes[eventType]
+	}
+
+	if s.excludedEventTypes[eventType] {
+		return false
+	}
+	return len(s.enabledEventTypes) == 0 || s.enabledEventTypes[eventType]
+}
+
+// recordEventMetrics records the generic metrics shared by all event types.
+func (l *OpenTelemetryLogger) recordEventMetrics(ctx context.Context, entry *LogEntry) {
+	opts := l.eventOptions(entry.EventType, entry.Domain)
+
+	l.eventsTotal.Add(ctx, 1, opts.add...)
+	if DurationValid(ctx) {
+		l.eventsDuration.Record(ctx, entry.Duration.Seconds(), opts.record...)
+	}
+}
+
+// recordEnforceMetrics records metrics for enforce events. The attribute
+// sets are cached per decision and domain, so recording does not allocate.
+func (l *OpenTelemetryLogger) recordEnforceMetrics(ctx context.Context, entry *LogEntry) {
+	opts := l.enforceOptions(entry.Allowed, entry.Domain)
+
+	if DurationValid(ctx) {
+		l.enforceDuration.Record(ctx, entry.Duration.Seconds(), opts.record...)
+	}
+	l.enforceTotal.Add(ctx, 1, opts.add...)
+}
+
+// recordBatchEnforceMetrics records metrics for batch enforce events.
+// The batch duration is recorded once, while every request in the batch
+// is counted individually by its decision and domain.
+func (l *OpenTelemetryLogger) recordBatchEnforceMetrics(ctx context.Context, entry *LogEntry) {
+	if DurationValid(ctx) {
+		l.batchDuration.Record(ctx, entry.Duration.Seconds(), l.withDomainAttributes(entry.Domain))
+	}
+
+	for _, request := range entry.Requests {
+		l.batchRequestTotal.Add(ctx, 1, l.enforceOptions(request.Allowed, request.Domain).add...)
+	}
+}
+
+// recordRoleLinksMetrics records metrics for role link build events.
+func (l *OpenTelemetryLogger) recordRoleLinksMetrics(ctx context.Context, entry *LogEntry) {
+	success := "true"
+	if entry.Error != nil {
+		success = "false"
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("success", success),
+	}
+
+	if DurationValid(ctx) {
+		l.roleLinksDuration.Record(ctx, entry.Duration.Seconds(), l.withDomainAttributes(entry.Domain, attrs...))
+	}
+
+	if entry.Error == nil {
+		l.roleLinksCount.Record(ctx, int64(entry.LinkCount), l.withDomainAttributes(entry.Domain))
+	}
+}
+
+// recordWatcherMetrics records metrics for watcher publish and receive events.
+// Propagation latency is only recorded for received updates that carry
+// their publish timestamp.
+func (l *OpenTelemetryLogger) recordWatcherMetrics(ctx context.Context, entry *LogEntry) {
+	operation := "publish"
+	if entry.EventType == EventWatcherReceive {
+		operation = "receive"
+	}
+
+	updateAttrs := []attribute.KeyValue{
+		attribute.String("operation", operation),
+		attribute.String("node_id", entry.NodeID),
+	}
+
+	l.watcherUpdates.Add(ctx, 1, l.withDomainAttributes(entry.Domain, updateAttrs...))
+
+	if entry.EventType != EventWatcherReceive || entry.PublishTime.IsZero() || !DurationValid(ctx) {
+		return
+	}
+
+	// Clock skew between nodes can make the latency negative, which is not
+	// a meaningful measurement.
+	latency := entry.StartTime.Sub(entry.PublishTime)
+	if latency < 0 {
+		return
+	}
+
+	latencyAttrs := []attribute.KeyValue{
+		attribute.String("node_id", entry.NodeID),
+	}
+	l.watcherLatency.Record(ctx, latency.Seconds(), l.withDomainAttributes(entry.Domain, latencyAttrs...))
+}
+
+// withAttributes returns a measurement option with the given attributes
+// and the attributes configured for the logger.
+func (l *OpenTelemetryLogger) withAttributes(attrs ...attribute.KeyValue) metric.MeasurementOption {
+	return l.mergeAttributes(nil, attrs)
+}
+
+// withDomainAttributes returns a measurement option with the given
+// attributes, the attributes configured for the logger and those of the
+// domain override matching the domain, if any.
+func (l *OpenTelemetryLogger) withDomainAttributes(domain string, attrs ...attribute.KeyValue) metric.MeasurementOption {
+	var domainAttrs []attribute.KeyValue
+	if override := l.settings.Load().domainOverride(domain); override != nil {
+		domainAttrs = override.attributes
+	}
+	return l.mergeAttributes(domainAttrs, attrs)
+}
+
+// mergeAttributes returns a measurement option with the attributes
+// configured for the logger, followed by the domain and event attributes.
+func (l *OpenTelemetryLogger) mergeAttributes(domainAttrs []attribute.KeyValue, attrs []attribute.KeyValue) metric.MeasurementOption {
+	if len(l.attributes) == 0 && len(domainAttrs) == 0 {
+		return metric.WithAttributes(attrs...)
+	}
+
+	all := make([]attribute.KeyValue, 0, len(l.attributes)+len(domainAttrs)+len(attrs))
+	all = append(all, l.attributes...)
+	all = append(all, domainAttrs...)
+	all = append(all, attrs...)
+	return metric.WithAttributes(all...)
+}
+
+// enforceAttributes returns the attributes describing an enforce decision.
+func enforceAttributes(allowed bool, domain string) []attribute.KeyValue {
 	if domain == "" {
 		domain = "default"
 	}
 
-	allowed := "false"
-	if entry.Allowed {
-		allowed = "true"
+	allowedValue := "false"
+	if allowed {
+		allowedValue = "true"
 	}
 
-	attrs := []attribute.KeyValue{
-		attribute.String("allowed", allowed),
+	return []attribute.KeyValue{
+		attribute.String("allowed", allowedValue),
 		attribute.String("domain", domain),
 	}
-
-	l.enforceDuration.Record(l.ctx, entry.Duration.Seconds(), metric.WithAttributes(attrs...))
-	l.enforceTotal.Add(l.ctx, 1, metric.WithAttributes(attrs...))
 }
 
 // recordPolicyMetrics records metrics for policy operation events.
-func (l *OpenTelemetryLogger) recordPolicyMetrics(entry *LogEntry) {
+func (l *OpenTelemetryLogger) recordPolicyMetrics(ctx context.Context, entry *LogEntry) {
 	operation := string(entry.EventType)
 	success := "true"
 	if entry.Error != nil {
@@ -191,14 +646,16 @@ func (l *OpenTelemetryLogger) recordPolicyMetrics(entry *LogEntry) {
 		attribute.String("operation", operation),
 	}
 
-	l.policyOpsTotal.Add(l.ctx, 1, metric.WithAttributes(opsAttrs...))
-	l.policyOpsDuration.Record(l.ctx, entry.Duration.Seconds(), metric.WithAttributes(durationAttrs...))
+	l.policyOpsTotal.Add(ctx, 1, l.withDomainAttributes(entry.Domain, opsAttrs...))
+	if DurationValid(ctx) {
+		l.policyOpsDuration.Record(ctx, entry.Duration.Seconds(), l.withDomainAttributes(entry.Domain, durationAttrs...))
+	}
 
 	if entry.RuleCount > 0 {
 		countAttrs := []attribute.KeyValue{
 			attribute.String("operation", operation),
 		}
-		l.policyRulesCount.Record(l.ctx, int64(entry.RuleCount), metric.WithAttributes(countAttrs...))
+		l.policyRulesCount.Record(ctx, int64(entry.RuleCount), l.withDomainAttributes(entry.Domain, countAttrs...))
 	}
 }
 
@@ -212,6 +669,16 @@ func (l *OpenTelemetryLogger) GetEnforceTotal() metric.Int64Counter {
 	return l.enforceTotal
 }
 
+// GetBatchEnforceDuration returns the batch enforce duration histogram metric.
+func (l *OpenTelemetryLogger) GetBatchEnforceDuration() metric.Float64Histogram {
+	return l.batchDuration
+}
+
+// GetBatchEnforceRequestsTotal returns the batch enforce requests counter metric.
+func (l *OpenTelemetryLogger) GetBatchEnforceRequestsTotal() metric.Int64Counter {
+	return l.batchRequestTotal
+}
+
 // GetPolicyOpsTotal returns the policy operations total counter metric.
 func (l *OpenTelemetryLogger) GetPolicyOpsTotal() metric.Int64Counter {
 	return l.policyOpsTotal
@@ -226,3 +693,63 @@ func (l *OpenTelemetryLogger) GetPolicyOpsDuration() metric.Float64Histogram {
 func (l *OpenTelemetryLogger) GetPolicyRulesCount() metric.Int64Gauge {
 	return l.policyRulesCount
 }
+
+// GetRoleLinksDuration returns the role links build duration histogram metric.
+func (l *OpenTelemetryLogger) GetRoleLinksDuration() metric.Float64Histogram {
+	return l.roleLinksDuration
+}
+
+// GetRoleLinksCount returns the role links count gauge metric.
+func (l *OpenTelemetryLogger) GetRoleLinksCount() metric.Int64Gauge {
+	return l.roleLinksCount
+}
+
+// GetWatcherUpdates returns the watcher updates counter metric.
+func (l *OpenTelemetryLogger) GetWatcherUpdates() metric.Int64Counter {
+	return l.watcherUpdates
+}
+
+// GetWatcherLatency returns the watcher propagation latency histogram metric.
+func (l *OpenTelemetryLogger) GetWatcherLatency() metric.Float64Histogram {
+	return l.watcherLatency
+}
+
+// GetEventsTotal returns the generic events total counter metric.
+func (l *OpenTelemetryLogger) GetEventsTotal() metric.Int64Counter {
+	return l.eventsTotal
+}
+
+// GetEventsDuration returns the generic events duration histogram metric.
+func (l *OpenTelemetryLogger) GetEventsDuration() metric.Float64Histogram {
+	return l.eventsDuration
+}
+
+// GetConfigVersion returns the config version gauge metric.
+func (l *OpenTelemetryLogger) GetConfigVersion() metric.Int64Gauge {
+	return l.configVersion
+}
+
+// GetConfigReloadErrors returns the config reload errors counter metric.
+func (l *OpenTelemetryLogger) GetConfigReloadErrors() metric.Int64Counter {
+	return l.configErrors
+}
+
+// GetCallbackFailures returns the callback failures counter metric.
+func (l *OpenTelemetryLogger) GetCallbackFailures() metric.Int64Counter {
+	return l.callbackFailures
+}
+
+// GetInvalidEntries returns the invalid entries counter metric.
+func (l *OpenTelemetryLogger) GetInvalidEntries() metric.Int64Counter {
+	return l.invalidEntries
+}
+
+// GetQueueDepth returns the delivery queue depth gauge metric.
+func (l *OpenTelemetryLogger) GetQueueDepth() metric.Int64ObservableGauge {
+	return l.queueDepth
+}
+
+// GetQueueDropped returns the delivery queue dropped entries counter metric.
+func (l *OpenTelemetryLogger) GetQueueDropped() metric.Int64Counter {
+	return l.queueDropped
+}
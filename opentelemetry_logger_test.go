@@ -38,7 +38,7 @@ func TestNewOpenTelemetryLogger(t *testing.T) {
 		t.Fatal("NewOpenTelemetryLogger returned nil")
 	}
 
-	if logger.enabledEventTypes == nil {
+	if logger.settings.Load().enabledEventTypes == nil {
 		t.Error("enabledEventTypes map not initialized")
 	}
 
@@ -50,6 +50,14 @@ func TestNewOpenTelemetryLogger(t *testing.T) {
 		t.Error("enforceTotal metric not initialized")
 	}
 
+	if logger.batchDuration == nil {
+		t.Error("batchDuration metric not initialized")
+	}
+
+	if logger.batchRequestTotal == nil {
+		t.Error("batchRequestTotal metric not initialized")
+	}
+
 	if logger.policyOpsTotal == nil {
 		t.Error("policyOpsTotal metric not initialized")
 	}
@@ -61,6 +69,38 @@ func TestNewOpenTelemetryLogger(t *testing.T) {
 	if logger.policyRulesCount == nil {
 		t.Error("policyRulesCount metric not initialized")
 	}
+
+	if logger.roleLinksDuration == nil {
+		t.Error("roleLinksDuration metric not initialized")
+	}
+
+	if logger.roleLinksCount == nil {
+		t.Error("roleLinksCount metric not initialized")
+	}
+
+	if logger.watcherUpdates == nil {
+		t.Error("watcherUpdates metric not initialized")
+	}
+
+	if logger.watcherLatency == nil {
+		t.Error("watcherLatency metric not initialized")
+	}
+
+	if logger.eventsTotal == nil {
+		t.Error("eventsTotal metric not initialized")
+	}
+
+	if logger.eventsDuration == nil {
+		t.Error("eventsDuration metric not initialized")
+	}
+
+	if logger.configVersion == nil {
+		t.Error("configVersion metric not initialized")
+	}
+
+	if logger.configErrors == nil {
+		t.Error("configErrors metric not initialized")
+	}
 }
 
 func TestNewOpenTelemetryLoggerWithContext(t *testing.T) {
@@ -99,23 +139,71 @@ func TestSetEventTypes(t *testing.T) {
 		t.Errorf("SetEventTypes returned error: %v", err)
 	}
 
-	if len(logger.enabledEventTypes) != 2 {
-		t.Errorf("Expected 2 enabled event types, got %d", len(logger.enabledEventTypes))
+	if len(logger.settings.Load().enabledEventTypes) != 2 {
+		t.Errorf("Expected 2 enabled event types, got %d", len(logger.settings.Load().enabledEventTypes))
 	}
 
-	if !logger.enabledEventTypes[EventEnforce] {
+	if !logger.settings.Load().enabledEventTypes[EventEnforce] {
 		t.Error("EventEnforce should be enabled")
 	}
 
-	if !logger.enabledEventTypes[EventAddPolicy] {
+	if !logger.settings.Load().enabledEventTypes[EventAddPolicy] {
 		t.Error("EventAddPolicy should be enabled")
 	}
 
-	if logger.enabledEventTypes[EventRemovePolicy] {
+	if logger.settings.Load().enabledEventTypes[EventRemovePolicy] {
 		t.Error("EventRemovePolicy should not be enabled")
 	}
 }
 
+func TestSetExcludedEventTypes(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	err = logger.SetExcludedEventTypes([]EventType{EventEnforce})
+	if err != nil {
+		t.Errorf("SetExcludedEventTypes returned error: %v", err)
+	}
+
+	testCases := []struct {
+		eventType EventType
+		active    bool
+	}{
+		{EventEnforce, false},
+		{EventAddPolicy, true},
+		{EventType("customEvent"), true},
+	}
+
+	for _, tc := range testCases {
+		entry := &LogEntry{EventType: tc.eventType}
+		logger.OnBeforeEvent(entry)
+		if entry.IsActive != tc.active {
+			t.Errorf("Expected %s active to be %v, got %v", tc.eventType, tc.active, entry.IsActive)
+		}
+	}
+
+	// Exclusions take precedence over enabled event types
+	logger.SetEventTypes([]EventType{EventEnforce, EventAddPolicy})
+	entry := &LogEntry{EventType: EventEnforce}
+	logger.OnBeforeEvent(entry)
+	if entry.IsActive {
+		t.Error("Excluded event type should not be active even if enabled")
+	}
+
+	logger.SetExcludedEventTypes(nil)
+	entry = &LogEntry{EventType: EventEnforce}
+	logger.OnBeforeEvent(entry)
+	if !entry.IsActive {
+		t.Error("Event type should be active after clearing exclusions")
+	}
+}
+
 func TestOnBeforeEvent(t *testing.T) {
 	reader := metric.NewManualReader()
 	provider := metric.NewMeterProvider(metric.WithReader(reader))
@@ -401,6 +489,42 @@ func TestSetLogCallback(t *testing.T) {
 	}
 }
 
+func TestSetLogCallback_Explanations(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var explanations [][]string
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		explanations = entry.Explanations
+		return nil
+	})
+
+	entry := &LogEntry{
+		EventType: EventEnforce,
+		Subject:   "alice",
+		Object:    "data1",
+		Action:    "write",
+	}
+	logger.OnBeforeEvent(entry)
+
+	entry.Allowed = false
+	entry.Explanations = [][]string{{"alice", "data1", "write", "deny"}}
+	err = logger.OnAfterEvent(entry)
+	if err != nil {
+		t.Errorf("OnAfterEvent returned error: %v", err)
+	}
+
+	if len(explanations) != 1 || len(explanations[0]) != 4 || explanations[0][3] != "deny" {
+		t.Errorf("Expected explanations to be forwarded to the callback, got %v", explanations)
+	}
+}
+
 func TestSetLogCallback_WithError(t *testing.T) {
 	reader := metric.NewManualReader()
 	provider := metric.NewMeterProvider(metric.WithReader(reader))
@@ -431,6 +555,248 @@ func TestSetLogCallback_WithError(t *testing.T) {
 	}
 }
 
+func TestOnAfterEvent_BatchEnforce(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	entry := &LogEntry{
+		IsActive:  true,
+		EventType: EventBatchEnforce,
+		StartTime: time.Now().Add(-20 * time.Millisecond),
+		Requests: []EnforceRequest{
+			{Subject: "alice", Object: "data1", Action: "read", Domain: "domain1", Allowed: true},
+			{Subject: "bob", Object: "data1", Action: "write", Domain: "domain1", Allowed: false},
+			{Subject: "carol", Object: "data2", Action: "read", Domain: "domain1", Allowed: true},
+			{Subject: "dave", Object: "data2", Action: "read", Allowed: false},
+		},
+	}
+
+	err = logger.OnAfterEvent(entry)
+	if err != nil {
+		t.Errorf("OnAfterEvent returned error: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	duration, ok := findMetric(rm, "casbin.enforce.batch.duration")
+	if !ok {
+		t.Fatal("Expected batch duration metric to be recorded")
+	}
+	histogram := duration.Data.(metricdata.Histogram[float64])
+	if len(histogram.DataPoints) != 1 || histogram.DataPoints[0].Count != 1 {
+		t.Errorf("Expected a single batch duration measurement, got %+v", histogram.DataPoints)
+	}
+
+	requests, ok := findMetric(rm, "casbin.enforce.batch.requests.total")
+	if !ok {
+		t.Fatal("Expected batch requests metric to be recorded")
+	}
+
+	expected := map[[2]string]int64{
+		{"true", "domain1"}:  2,
+		{"false", "domain1"}: 1,
+		{"false", "default"}: 1,
+	}
+	sum := requests.Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != len(expected) {
+		t.Fatalf("Expected %d data points, got %d", len(expected), len(sum.DataPoints))
+	}
+	for _, dp := range sum.DataPoints {
+		allowed, _ := dp.Attributes.Value("allowed")
+		domain, _ := dp.Attributes.Value("domain")
+		key := [2]string{allowed.AsString(), domain.AsString()}
+		if dp.Value != expected[key] {
+			t.Errorf("Expected %d requests for %v, got %d", expected[key], key, dp.Value)
+		}
+	}
+
+	if _, ok := findMetric(rm, "casbin.enforce.total"); ok {
+		t.Error("Batch enforce should not be recorded as a single enforce request")
+	}
+}
+
+func TestOnAfterEvent_BuildRoleLinks(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	entry := &LogEntry{
+		EventType: EventBuildRoleLinks,
+	}
+
+	logger.OnBeforeEvent(entry)
+	if !entry.IsActive {
+		t.Fatal("Build role links entry should be active")
+	}
+
+	entry.LinkCount = 42
+	err = logger.OnAfterEvent(entry)
+	if err != nil {
+		t.Errorf("OnAfterEvent returned error: %v", err)
+	}
+
+	if entry.EndTime.IsZero() {
+		t.Error("EndTime should be set")
+	}
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	duration, ok := findMetric(rm, "casbin.role_links.build.duration")
+	if !ok {
+		t.Fatal("Expected role links duration metric to be recorded")
+	}
+	histogram := duration.Data.(metricdata.Histogram[float64])
+	if len(histogram.DataPoints) != 1 || histogram.DataPoints[0].Count != 1 {
+		t.Errorf("Expected a single duration measurement, got %+v", histogram.DataPoints)
+	}
+
+	count, ok := findMetric(rm, "casbin.role_links.count")
+	if !ok {
+		t.Fatal("Expected role links count metric to be recorded")
+	}
+	gauge := count.Data.(metricdata.Gauge[int64])
+	if len(gauge.DataPoints) != 1 || gauge.DataPoints[0].Value != 42 {
+		t.Errorf("Expected role links count of 42, got %+v", gauge.DataPoints)
+	}
+}
+
+func TestOnAfterEvent_Watcher(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	publishTime := time.Now().Add(-250 * time.Millisecond)
+
+	publishEntry := &LogEntry{
+		IsActive:  true,
+		EventType: EventWatcherPublish,
+		StartTime: publishTime,
+		NodeID:    "node-a",
+	}
+	logger.OnAfterEvent(publishEntry)
+
+	receiveEntry := &LogEntry{
+		EventType:   EventWatcherReceive,
+		NodeID:      "node-a",
+		PublishTime: publishTime,
+	}
+	logger.OnBeforeEvent(receiveEntry)
+	logger.OnAfterEvent(receiveEntry)
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	updates, ok := findMetric(rm, "casbin.watcher.updates.total")
+	if !ok {
+		t.Fatal("Expected watcher updates metric to be recorded")
+	}
+	sum := updates.Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != 2 {
+		t.Fatalf("Expected 2 data points, got %d", len(sum.DataPoints))
+	}
+	for _, dp := range sum.DataPoints {
+		nodeID, _ := dp.Attributes.Value("node_id")
+		if nodeID.AsString() != "node-a" || dp.Value != 1 {
+			t.Errorf("Unexpected data point: %+v", dp)
+		}
+	}
+
+	latency, ok := findMetric(rm, "casbin.watcher.propagation.latency")
+	if !ok {
+		t.Fatal("Expected watcher latency metric to be recorded")
+	}
+	histogram := latency.Data.(metricdata.Histogram[float64])
+	if len(histogram.DataPoints) != 1 || histogram.DataPoints[0].Count != 1 {
+		t.Fatalf("Expected a single latency measurement, got %+v", histogram.DataPoints)
+	}
+	if histogram.DataPoints[0].Sum < 0.25 {
+		t.Errorf("Expected latency of at least 0.25s, got %v", histogram.DataPoints[0].Sum)
+	}
+}
+
+func TestOnAfterEvent_GenericMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	entries := []*LogEntry{
+		{EventType: EventEnforce, Allowed: true},
+		{EventType: EventType("customEvent")},
+		{EventType: EventType("customEvent")},
+	}
+	for _, entry := range entries {
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	total, ok := findMetric(rm, "casbin.events.total")
+	if !ok {
+		t.Fatal("Expected events total metric to be recorded")
+	}
+
+	expected := map[string]int64{
+		"enforce":     1,
+		"customEvent": 2,
+	}
+	sum := total.Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != len(expected) {
+		t.Fatalf("Expected %d data points, got %d", len(expected), len(sum.DataPoints))
+	}
+	for _, dp := range sum.DataPoints {
+		eventType, _ := dp.Attributes.Value("event_type")
+		if dp.Value != expected[eventType.AsString()] {
+			t.Errorf("Expected %d events for %s, got %d", expected[eventType.AsString()], eventType.AsString(), dp.Value)
+		}
+	}
+
+	duration, ok := findMetric(rm, "casbin.events.duration")
+	if !ok {
+		t.Fatal("Expected events duration metric to be recorded")
+	}
+	histogram := duration.Data.(metricdata.Histogram[float64])
+	if len(histogram.DataPoints) != len(expected) {
+		t.Errorf("Expected %d data points, got %d", len(expected), len(histogram.DataPoints))
+	}
+}
+
 func TestEnforceMetrics_DifferentDomains(t *testing.T) {
 	reader := metric.NewManualReader()
 	provider := metric.NewMeterProvider(metric.WithReader(reader))
@@ -493,6 +859,14 @@ func TestMetricGetters(t *testing.T) {
 		t.Error("GetEnforceTotal returned nil")
 	}
 
+	if logger.GetBatchEnforceDuration() == nil {
+		t.Error("GetBatchEnforceDuration returned nil")
+	}
+
+	if logger.GetBatchEnforceRequestsTotal() == nil {
+		t.Error("GetBatchEnforceRequestsTotal returned nil")
+	}
+
 	if logger.GetPolicyOpsTotal() == nil {
 		t.Error("GetPolicyOpsTotal returned nil")
 	}
@@ -504,6 +878,38 @@ func TestMetricGetters(t *testing.T) {
 	if logger.GetPolicyRulesCount() == nil {
 		t.Error("GetPolicyRulesCount returned nil")
 	}
+
+	if logger.GetRoleLinksDuration() == nil {
+		t.Error("GetRoleLinksDuration returned nil")
+	}
+
+	if logger.GetRoleLinksCount() == nil {
+		t.Error("GetRoleLinksCount returned nil")
+	}
+
+	if logger.GetWatcherUpdates() == nil {
+		t.Error("GetWatcherUpdates returned nil")
+	}
+
+	if logger.GetWatcherLatency() == nil {
+		t.Error("GetWatcherLatency returned nil")
+	}
+
+	if logger.GetEventsTotal() == nil {
+		t.Error("GetEventsTotal returned nil")
+	}
+
+	if logger.GetEventsDuration() == nil {
+		t.Error("GetEventsDuration returned nil")
+	}
+
+	if logger.GetConfigVersion() == nil {
+		t.Error("GetConfigVersion returned nil")
+	}
+
+	if logger.GetConfigReloadErrors() == nil {
+		t.Error("GetConfigReloadErrors returned nil")
+	}
 }
 
 func TestLogger_InterfaceImplementation(t *testing.T) {
@@ -579,3 +985,94 @@ func TestFullWorkflow(t *testing.T) {
 		t.Error("Expected enforce metrics to be recorded")
 	}
 }
+
+func TestOnAfterEvent_InvalidTiming(t *testing.T) {
+	testCases := []struct {
+		name      string
+		startTime time.Time
+		reason    string
+	}{
+		{"Missing start time", time.Time{}, "missing_start_time"},
+		{"Future start time", time.Now().Add(time.Hour), "negative_duration"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := metric.NewManualReader()
+			provider := metric.NewMeterProvider(metric.WithReader(reader))
+			meter := provider.Meter("test")
+
+			logger, err := NewOpenTelemetryLogger(meter)
+			if err != nil {
+				t.Fatalf("Setup failed: %v", err)
+			}
+
+			var delivered *LogEntry
+			logger.SetLogCallback(func(entry *LogEntry) error {
+				delivered = entry
+				return nil
+			})
+
+			entry := &LogEntry{
+				IsActive:  true,
+				EventType: EventEnforce,
+				StartTime: tc.startTime,
+			}
+			if err := logger.OnAfterEvent(entry); err != nil {
+				t.Errorf("OnAfterEvent returned error: %v", err)
+			}
+
+			if delivered == nil || delivered.Duration != 0 {
+				t.Errorf("Expected the entry to be delivered with a zero duration, got %+v", delivered)
+			}
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(context.Background(), &rm); err != nil {
+				t.Fatalf("Failed to collect metrics: %v", err)
+			}
+
+			if _, ok := findMetric(rm, "casbin.enforce.duration"); ok {
+				t.Error("Expected no duration to be recorded for an invalid entry")
+			}
+			if _, ok := findMetric(rm, "casbin.events.duration"); ok {
+				t.Error("Expected no event duration to be recorded for an invalid entry")
+			}
+
+			// The decision is still counted
+			for _, name := range []string{"casbin.enforce.total", "casbin.events.total"} {
+				total, ok := findMetric(rm, name)
+				if !ok {
+					t.Fatalf("Expected %s to be recorded", name)
+				}
+				dps := total.Data.(metricdata.Sum[int64]).DataPoints
+				if len(dps) != 1 || dps[0].Value != 1 {
+					t.Errorf("Expected %s to be 1, got %+v", name, dps)
+				}
+			}
+
+			invalid, ok := findMetric(rm, "casbin.logger.invalid_entries")
+			if !ok {
+				t.Fatal("Expected the invalid entry to be counted")
+			}
+			dps := invalid.Data.(metricdata.Sum[int64]).DataPoints
+			if len(dps) != 1 || dps[0].Value != 1 {
+				t.Fatalf("Expected 1 invalid entry, got %+v", dps)
+			}
+			if reason, _ := dps[0].Attributes.Value("reason"); reason.AsString() != tc.reason {
+				t.Errorf("Expected reason %q, got %q", tc.reason, reason.AsString())
+			}
+		})
+	}
+}
+
+// findMetric returns the metric with the given name from the collected data.
+func findMetric(rm metricdata.ResourceMetrics, name string) (metricdata.Metrics, bool) {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true
+			}
+		}
+	}
+	return metricdata.Metrics{}, false
+}
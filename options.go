@@ -0,0 +1,243 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// defaultMetricPrefix is the prefix of the names of the built-in metrics.
+const defaultMetricPrefix = "casbin"
+
+// Option configures an OpenTelemetryLogger when it is created.
+type Option func(*options)
+
+// options holds the configuration used to create a logger.
+type options struct {
+	metricPrefix       string
+	attributes         []attribute.KeyValue
+	durationBuckets    []float64
+	eventTypes         []EventType
+	excludedEventTypes []EventType
+	filter             Filter
+	sampler            Sampler
+	slowThreshold      time.Duration
+	slowThresholds     map[EventType]time.Duration
+	redactedFields     []string
+	debugSubjects      []string
+	sinks              []Sink
+	domains            map[string]DomainOverride
+	callbackErrorMode  CallbackErrorMode
+	callbackTimeout    time.Duration
+	async              *AsyncConfig
+	clock              Clock
+}
+
+// buildOptions returns the default options modified by each set of options
+// in turn, so that later sets take precedence.
+func buildOptions(optionSets ...[]Option) *options {
+	o := &options{
+		metricPrefix:   defaultMetricPrefix,
+		slowThresholds: make(map[EventType]time.Duration),
+		domains:        make(map[string]DomainOverride),
+		clock:          systemClock{},
+	}
+
+	for _, opts := range optionSets {
+		for _, opt := range opts {
+			opt(o)
+		}
+	}
+
+	return o
+}
+
+// settings returns the initial runtime settings for the options.
+func (o *options) settings() *settings {
+	s := newSettings()
+	o.applyTo(s)
+	s.sinks = o.sinks
+	s.callbackErrorMode = o.callbackErrorMode
+	s.callbackTimeout = o.callbackTimeout
+	return s
+}
+
+// applyTo sets the runtime settings that can be configured declaratively.
+func (o *options) applyTo(s *settings) {
+	s.enabledEventTypes = eventTypeSet(o.eventTypes)
+	s.excludedEventTypes = eventTypeSet(o.excludedEventTypes)
+	s.filter = o.filter
+	s.sampler = o.sampler
+	s.slowThreshold = o.slowThreshold
+	s.slowThresholds = copyMap(o.slowThresholds)
+	s.redactedFields = fieldSet(o.redactedFields)
+	s.debugSubjects = fieldSet(o.debugSubjects)
+
+	s.domains = make(map[string]*domainOverride, len(o.domains))
+	for pattern, override := range o.domains {
+		s.domains[pattern] = newDomainOverride(override)
+	}
+}
+
+// durationHistogramOptions returns the options of a duration histogram with
+// the given description.
+func (o *options) durationHistogramOptions(description string) []metric.Float64HistogramOption {
+	opts := []metric.Float64HistogramOption{
+		metric.WithDescription(description),
+		metric.WithUnit("s"),
+	}
+	if len(o.durationBuckets) > 0 {
+		opts = append(opts, metric.WithExplicitBucketBoundaries(o.durationBuckets...))
+	}
+	return opts
+}
+
+// WithMetricPrefix sets the prefix of the names of the built-in metrics,
+// "casbin" by default.
+func WithMetricPrefix(prefix string) Option {
+	return func(o *options) {
+		o.metricPrefix = prefix
+	}
+}
+
+// WithAttributes sets attributes that are added to every measurement of the
+// built-in metrics, such as the service or cluster name.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return func(o *options) {
+		o.attributes = attrs
+	}
+}
+
+// WithDurationBuckets sets the explicit bucket boundaries, in seconds, of
+// the built-in duration histograms.
+func WithDurationBuckets(bounds ...float64) Option {
+	return func(o *options) {
+		o.durationBuckets = bounds
+	}
+}
+
+// WithEventTypes sets the event types to log, as SetEventTypes does.
+func WithEventTypes(eventTypes ...EventType) Option {
+	return func(o *options) {
+		o.eventTypes = eventTypes
+	}
+}
+
+// WithExcludedEventTypes sets the event types never to log, as
+// SetExcludedEventTypes does.
+func WithExcludedEventTypes(eventTypes ...EventType) Option {
+	return func(o *options) {
+		o.excludedEventTypes = eventTypes
+	}
+}
+
+// WithFilter sets the entry filter, as SetFilter does.
+func WithFilter(filter Filter) Option {
+	return func(o *options) {
+		o.filter = filter
+	}
+}
+
+// WithSampler sets the sampler for callback delivery, as SetSampler does.
+func WithSampler(sampler Sampler) Option {
+	return func(o *options) {
+		o.sampler = sampler
+	}
+}
+
+// WithSlowThreshold sets the global slow-event threshold, as
+// SetSlowThreshold does.
+func WithSlowThreshold(threshold time.Duration) Option {
+	return func(o *options) {
+		o.slowThreshold = threshold
+	}
+}
+
+// WithSlowThresholdFor sets the slow-event threshold of an event type, as
+// SetSlowThresholdFor does.
+func WithSlowThresholdFor(eventType EventType, threshold time.Duration) Option {
+	return func(o *options) {
+		if threshold == 0 {
+			delete(o.slowThresholds, eventType)
+			return
+		}
+		o.slowThresholds[eventType] = threshold
+	}
+}
+
+// WithRedactedFields sets the fields redacted from delivered entries, as
+// SetRedactedFields does.
+func WithRedactedFields(fields ...string) Option {
+	return func(o *options) {
+		o.redactedFields = fields
+	}
+}
+
+// WithDebugSubjects sets the subjects whose entries are always delivered,
+// as SetDebugSubjects does.
+func WithDebugSubjects(subjects ...string) Option {
+	return func(o *options) {
+		o.debugSubjects = subjects
+	}
+}
+
+// WithSinks adds sinks that entries are delivered to, as AddSink does.
+func WithSinks(sinks ...Sink) Option {
+	return func(o *options) {
+		o.sinks = append(o.sinks, sinks...)
+	}
+}
+
+// WithCallbackErrorMode sets whether delivery continues after a callback
+// or sink returns an error, as SetCallbackErrorMode does.
+func WithCallbackErrorMode(mode CallbackErrorMode) Option {
+	return func(o *options) {
+		o.callbackErrorMode = mode
+	}
+}
+
+// WithCallbackTimeout sets the time each callback may take to handle an
+// entry, as SetCallbackTimeout does.
+func WithCallbackTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.callbackTimeout = timeout
+	}
+}
+
+// WithDomainOverride overrides the configuration for the domains matching
+// the pattern, as SetDomainOverride does. An empty pattern is ignored.
+func WithDomainOverride(pattern string, override DomainOverride) Option {
+	return func(o *options) {
+		if pattern == "" {
+			return
+		}
+		o.domains[pattern] = override
+	}
+}
+
+// withDomainSinks sets the sinks of the domain overrides opened from a
+// configuration file.
+func withDomainSinks(domainSinks map[string][]Sink) Option {
+	return func(o *options) {
+		for pattern, sinks := range domainSinks {
+			override := o.domains[pattern]
+			override.Sinks = sinks
+			o.domains[pattern] = override
+		}
+	}
+}
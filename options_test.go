@@ -0,0 +1,117 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestWithMetricPrefix(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithMetricPrefix("authz"))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	entry := &LogEntry{EventType: EventEnforce, Allowed: true}
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	if _, ok := findMetric(rm, "authz.enforce.total"); !ok {
+		t.Error("Expected metric names to use the configured prefix")
+	}
+
+	if _, ok := findMetric(rm, "casbin.enforce.total"); ok {
+		t.Error("Expected the default prefix not to be used")
+	}
+}
+
+func TestWithAttributes(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithAttributes(attribute.String("cluster", "eu-1")))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	entry := &LogEntry{EventType: EventEnforce, Domain: "domain1", Allowed: true}
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	total, ok := findMetric(rm, "casbin.enforce.total")
+	if !ok {
+		t.Fatal("Expected enforce metrics to be recorded")
+	}
+	dp := total.Data.(metricdata.Sum[int64]).DataPoints[0]
+
+	cluster, _ := dp.Attributes.Value("cluster")
+	domain, _ := dp.Attributes.Value("domain")
+	if cluster.AsString() != "eu-1" || domain.AsString() != "domain1" {
+		t.Errorf("Expected configured and event attributes, got %v", dp.Attributes.ToSlice())
+	}
+}
+
+func TestRuntimeOptions(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	sampler := NewProbabilitySampler(0.5)
+	logger, err := NewOpenTelemetryLogger(meter,
+		WithEventTypes(EventEnforce, EventAddPolicy),
+		WithExcludedEventTypes(EventAddPolicy),
+		WithSampler(sampler),
+		WithSlowThreshold(time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	s := logger.settings.Load()
+	if !s.eventTypeEnabled(EventEnforce, "") || s.eventTypeEnabled(EventAddPolicy, "") || s.eventTypeEnabled(EventSavePolicy, "") {
+		t.Errorf("Unexpected event types: enabled %v, excluded %v", s.enabledEventTypes, s.excludedEventTypes)
+	}
+
+	if s.sampler != sampler {
+		t.Error("Sampler not set")
+	}
+
+	if s.slowThreshold != time.Second {
+		t.Errorf("Expected slow threshold of 1s, got %v", s.slowThreshold)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import "context"
+
+// Recorder records metrics for completed log entries of an event type.
+type Recorder interface {
+	// Record is called with every active entry of the event type the
+	// recorder is registered for, after its duration has been computed.
+	// Entries with invalid timing are recorded with a zero duration, and
+	// DurationValid reports false for ctx.
+	Record(ctx context.Context, entry *LogEntry)
+}
+
+// invalidDurationKey marks the context of recordings whose entry has
+// invalid timing.
+type invalidDurationKey struct{}
+
+// DurationValid reports whether the duration of the entry being recorded
+// with ctx is valid. Recorders should skip their duration measurements when
+// it is not, such as when OnAfterEvent is called without OnBeforeEvent, and
+// still record the rest.
+func DurationValid(ctx context.Context) bool {
+	return ctx.Value(invalidDurationKey{}) == nil
+}
+
+// RecorderFunc is an adapter to allow the use of ordinary functions as Recorders.
+type RecorderFunc func(ctx context.Context, entry *LogEntry)
+
+// Record calls f(ctx, entry).
+func (f RecorderFunc) Record(ctx context.Context, entry *LogEntry) {
+	f(ctx, entry)
+}
+
+// RegisterRecorder registers the recorder used for the given event type,
+// replacing any recorder previously registered for it, including the
+// built-in ones. Registering a nil recorder removes the registration, so
+// only the generic event metrics are recorded for that event type.
+func (l *OpenTelemetryLogger) RegisterRecorder(eventType EventType, recorder Recorder) error {
+	l.update(func(s *settings) {
+		if recorder == nil {
+			delete(s.recorders, eventType)
+			return
+		}
+		s.recorders[eventType] = recorder
+	})
+	return nil
+}
+
+// registerDefaultRecorders registers the built-in recorders.
+func (l *OpenTelemetryLogger) registerDefaultRecorders() {
+	policyRecorder := RecorderFunc(l.recordPolicyMetrics)
+	watcherRecorder := RecorderFunc(l.recordWatcherMetrics)
+
+	l.update(func(s *settings) {
+		s.recorders[EventEnforce] = RecorderFunc(l.recordEnforceMetrics)
+		s.recorders[EventBatchEnforce] = RecorderFunc(l.recordBatchEnforceMetrics)
+		s.recorders[EventAddPolicy] = policyRecorder
+		s.recorders[EventRemovePolicy] = policyRecorder
+		s.recorders[EventLoadPolicy] = policyRecorder
+		s.recorders[EventSavePolicy] = policyRecorder
+		s.recorders[EventBuildRoleLinks] = RecorderFunc(l.recordRoleLinksMetrics)
+		s.recorders[EventWatcherPublish] = watcherRecorder
+		s.recorders[EventWatcherReceive] = watcherRecorder
+	})
+}
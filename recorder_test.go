@@ -0,0 +1,196 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestDefaultRecorders(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	eventTypes := []EventType{
+		EventEnforce,
+		EventBatchEnforce,
+		EventAddPolicy,
+		EventRemovePolicy,
+		EventLoadPolicy,
+		EventSavePolicy,
+		EventBuildRoleLinks,
+		EventWatcherPublish,
+		EventWatcherReceive,
+	}
+
+	for _, eventType := range eventTypes {
+		if _, ok := logger.settings.Load().recorders[eventType]; !ok {
+			t.Errorf("Expected a default recorder for %s", eventType)
+		}
+	}
+}
+
+func TestRegisterRecorder_CustomEventType(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	customEvent := EventType("customEvent")
+	var recorded []*LogEntry
+	err = logger.RegisterRecorder(customEvent, RecorderFunc(func(ctx context.Context, entry *LogEntry) {
+		recorded = append(recorded, entry)
+	}))
+	if err != nil {
+		t.Errorf("RegisterRecorder returned error: %v", err)
+	}
+
+	entry := &LogEntry{
+		IsActive:  true,
+		EventType: customEvent,
+		StartTime: time.Now(),
+	}
+	logger.OnAfterEvent(entry)
+
+	if len(recorded) != 1 || recorded[0] != entry {
+		t.Fatalf("Expected custom recorder to be called once with the entry, got %v", recorded)
+	}
+
+	if recorded[0].EndTime.IsZero() {
+		t.Error("Recorder should be called after timing is computed")
+	}
+}
+
+func TestRegisterRecorder_ReplaceDefault(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	calls := 0
+	logger.RegisterRecorder(EventEnforce, RecorderFunc(func(ctx context.Context, entry *LogEntry) {
+		calls++
+	}))
+
+	entry := &LogEntry{
+		IsActive:  true,
+		EventType: EventEnforce,
+		StartTime: time.Now(),
+		Allowed:   true,
+	}
+	logger.OnAfterEvent(entry)
+
+	if calls != 1 {
+		t.Errorf("Expected replacement recorder to be called once, got %d", calls)
+	}
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	if _, ok := findMetric(rm, "casbin.enforce.total"); ok {
+		t.Error("Default enforce recorder should have been replaced")
+	}
+
+	if _, ok := findMetric(rm, "casbin.events.total"); !ok {
+		t.Error("Generic event metrics should still be recorded")
+	}
+}
+
+func TestRegisterRecorder_Nil(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	err = logger.RegisterRecorder(EventAddPolicy, nil)
+	if err != nil {
+		t.Errorf("RegisterRecorder returned error: %v", err)
+	}
+
+	if _, ok := logger.settings.Load().recorders[EventAddPolicy]; ok {
+		t.Error("Recorder for EventAddPolicy should have been removed")
+	}
+
+	entry := &LogEntry{
+		IsActive:  true,
+		EventType: EventAddPolicy,
+		StartTime: time.Now(),
+		RuleCount: 1,
+	}
+	logger.OnAfterEvent(entry)
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	if _, ok := findMetric(rm, "casbin.policy.operations.total"); ok {
+		t.Error("Policy metrics should not be recorded without a recorder")
+	}
+}
+
+func TestRegisterRecorder_DurationValid(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	customEvent := EventType("customEvent")
+	var valid []bool
+	logger.RegisterRecorder(customEvent, RecorderFunc(func(ctx context.Context, entry *LogEntry) {
+		valid = append(valid, DurationValid(ctx))
+	}))
+
+	entry := &LogEntry{EventType: customEvent}
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	// Recorders are still called for entries with invalid timing
+	logger.OnAfterEvent(&LogEntry{IsActive: true, EventType: customEvent})
+
+	if len(valid) != 2 || !valid[0] || valid[1] {
+		t.Errorf("Expected only the first duration to be valid, got %v", valid)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import "fmt"
+
+// Fields that can be redacted from the entries delivered to callbacks and sinks.
+const (
+	FieldSubject      = "subject"
+	FieldObject       = "object"
+	FieldAction       = "action"
+	FieldDomain       = "domain"
+	FieldRules        = "rules"
+	FieldExplanations = "explanations"
+)
+
+// RedactedValue replaces the value of redacted string fields.
+const RedactedValue = "[REDACTED]"
+
+// redactableFields is the set of fields that can be redacted.
+var redactableFields = map[string]bool{
+	FieldSubject:      true,
+	FieldObject:       true,
+	FieldAction:       true,
+	FieldDomain:       true,
+	FieldRules:        true,
+	FieldExplanations: true,
+}
+
+// SetRedactedFields configures the fields redacted from the entries
+// delivered to the callback and sinks. String fields are replaced with
+// RedactedValue, including those of batch requests, while rules and
+// explanations are removed. Metrics and the caller's entry are not affected.
+func (l *OpenTelemetryLogger) SetRedactedFields(fields []string) error {
+	for _, field := range fields {
+		if err := validateField(field); err != nil {
+			return err
+		}
+	}
+
+	l.update(func(s *settings) {
+		s.redactedFields = fieldSet(fields)
+	})
+	return nil
+}
+
+// validateField returns an error if the field cannot be redacted.
+func validateField(field string) error {
+	if !redactableFields[field] {
+		return fmt.Errorf("unknown redacted field %q", field)
+	}
+	return nil
+}
+
+// fieldSet returns the set of the given fields.
+func fieldSet(fields []string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[field] = true
+	}
+	return set
+}
+
+// redact returns the entry with the configured fields redacted. The entry
+// itself is returned if no fields are redacted, and a copy otherwise.
+func (s *settings) redact(entry *LogEntry) *LogEntry {
+	if len(s.redactedFields) == 0 {
+		return entry
+	}
+
+	redacted := *entry
+	s.redactString(FieldSubject, &redacted.Subject)
+	s.redactString(FieldObject, &redacted.Object)
+	s.redactString(FieldAction, &redacted.Action)
+	s.redactString(FieldDomain, &redacted.Domain)
+
+	if s.redactedFields[FieldRules] {
+		redacted.Rules = nil
+	}
+
+	if s.redactedFields[FieldExplanations] {
+		redacted.Explanations = nil
+	}
+
+	if len(entry.Requests) > 0 {
+		redacted.Requests = make([]EnforceRequest, len(entry.Requests))
+		for i, request := range entry.Requests {
+			s.redactString(FieldSubject, &request.Subject)
+			s.redactString(FieldObject, &request.Object)
+			s.redactString(FieldAction, &request.Action)
+			s.redactString(FieldDomain, &request.Domain)
+			redacted.Requests[i] = request
+		}
+	}
+
+	return &redacted
+}
+
+// redactString replaces the value if the field is redacted and not empty.
+func (s *settings) redactString(field string, value *string) {
+	if s.redactedFields[field] && *value != "" {
+		*value = RedactedValue
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestSetRedactedFields(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	err = logger.SetRedactedFields([]string{FieldSubject, FieldRules, FieldExplanations})
+	if err != nil {
+		t.Errorf("SetRedactedFields returned error: %v", err)
+	}
+
+	var delivered *LogEntry
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		delivered = entry
+		return nil
+	})
+
+	entry := &LogEntry{
+		EventType:    EventEnforce,
+		Subject:      "alice",
+		Object:       "data1",
+		Rules:        [][]string{{"alice", "data1", "read"}},
+		Explanations: [][]string{{"alice", "data1", "read"}},
+	}
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	if delivered == nil {
+		t.Fatal("Expected the entry to be delivered")
+	}
+
+	if delivered.Subject != RedactedValue || delivered.Rules != nil || delivered.Explanations != nil {
+		t.Errorf("Expected subject, rules and explanations to be redacted, got %+v", delivered)
+	}
+
+	if delivered.Object != "data1" {
+		t.Errorf("Expected object not to be redacted, got %s", delivered.Object)
+	}
+
+	if entry.Subject != "alice" || entry.Rules == nil {
+		t.Error("The caller's entry should not be redacted")
+	}
+}
+
+func TestSetRedactedFields_BatchRequests(t *testing.T) {
+	s := newSettings()
+	s.redactedFields = fieldSet([]string{FieldDomain})
+
+	entry := &LogEntry{
+		EventType: EventBatchEnforce,
+		Requests: []EnforceRequest{
+			{Subject: "alice", Domain: "domain1"},
+			{Subject: "bob"},
+		},
+	}
+
+	redacted := s.redact(entry)
+
+	if redacted.Requests[0].Domain != RedactedValue || redacted.Requests[0].Subject != "alice" {
+		t.Errorf("Expected only the domain to be redacted, got %+v", redacted.Requests[0])
+	}
+
+	if redacted.Requests[1].Domain != "" {
+		t.Errorf("Empty values should stay empty, got %q", redacted.Requests[1].Domain)
+	}
+
+	if entry.Requests[0].Domain != "domain1" {
+		t.Error("The original requests should not be redacted")
+	}
+}
+
+func TestSetRedactedFields_Unknown(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	if err := logger.SetRedactedFields([]string{"password"}); err == nil {
+		t.Error("Expected an error for an unknown field")
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ApplyConfig atomically applies the runtime settings of the configuration:
+// event types, filter, sampling, slow-event thresholds, redacted fields,
+// debug subjects and domain overrides. Runtime settings missing from the
+// configuration are reset to their defaults, while those set from the
+// environment or by options passed when the logger was created keep their
+// precedence. The metric prefix, attributes, histogram buckets and sinks
+// are fixed when the logger is created and are left unchanged; domain
+// overrides keep the sinks they were created with, and the sinks opened
+// from the configuration for a pattern are restored if the pattern is
+// removed and later added back. Those sinks stay open until Shutdown.
+func (l *OpenTelemetryLogger) ApplyConfig(config *Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	// Keep the precedence of the environment, the configuration and the
+	// explicit options used when the logger was created
+	o := buildOptions(l.envOptions, config.runtimeOptions(), l.explicitOptions)
+
+	l.update(func(s *settings) {
+		previous := s.domains
+		o.applyTo(s)
+		for pattern, override := range s.domains {
+			if override.sinks != nil {
+				continue
+			}
+			if p, ok := previous[pattern]; ok {
+				override.sinks = p.sinks
+			} else if sinks, ok := l.domainSinks[pattern]; ok {
+				override.sinks = sinks
+			}
+		}
+	})
+
+	l.recordConfigVersion()
+	return nil
+}
+
+// WatchConfigFile polls the JSON configuration file at path every interval
+// and applies its runtime settings with ApplyConfig whenever its content
+// changes, until ctx is done or the logger is shut down. The file is
+// expected to have already been applied, for example with
+// NewOpenTelemetryLoggerFromFile, so it is only read to detect changes.
+// Reload errors are counted in the casbin.logger.config.reload.errors
+// metric and reported to the OpenTelemetry error handler.
+func (l *OpenTelemetryLogger) WatchConfigFile(ctx context.Context, path string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("config watch interval must be positive, got %v", interval)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.isShutdown.Load() {
+		return ErrLoggerShutdown
+	}
+
+	l.watchers.Add(1)
+	go l.watchConfigFile(ctx, path, interval, data)
+	return nil
+}
+
+// watchConfigFile polls the configuration file until ctx is done or the
+// logger is shut down.
+func (l *OpenTelemetryLogger) watchConfigFile(ctx context.Context, path string, interval time.Duration, last []byte) {
+	defer l.watchers.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-l.done:
+			return
+		case <-ticker.C:
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			l.reloadFailed("read", err)
+			continue
+		}
+
+		if bytes.Equal(data, last) {
+			continue
+		}
+		last = data
+
+		config, err := ParseConfig(data)
+		if err != nil {
+			l.reloadFailed("invalid", err)
+			continue
+		}
+
+		if err := l.ApplyConfig(config); err != nil {
+			l.reloadFailed("invalid", err)
+		}
+	}
+}
+
+// recordConfigVersion increments and records the configuration version.
+func (l *OpenTelemetryLogger) recordConfigVersion() {
+	l.configVersion.Record(l.ctx, l.version.Add(1), l.withAttributes())
+}
+
+// reloadFailed records a failed configuration reload.
+func (l *OpenTelemetryLogger) reloadFailed(reason string, err error) {
+	l.configErrors.Add(l.ctx, 1, l.withAttributes(attribute.String("reason", reason)))
+	otel.Handle(fmt.Errorf("reload logger config: %w", err))
+}
@@ -0,0 +1,363 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestApplyConfig(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithMetricPrefix("authz"))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	callback := func(entry *LogEntry) error { return nil }
+	logger.SetLogCallback(callback)
+	logger.SetSlowThreshold(time.Second)
+
+	err = logger.ApplyConfig(&Config{
+		MetricPrefix:  "ignored",
+		EventTypes:    []EventType{EventEnforce},
+		Sampling:      &SamplingConfig{Type: SamplingProbability, Rate: 0.5},
+		DebugSubjects: []string{"alice"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyConfig returned error: %v", err)
+	}
+
+	s := logger.settings.Load()
+	if !s.eventTypeEnabled(EventEnforce, "") || s.eventTypeEnabled(EventAddPolicy, "") {
+		t.Errorf("Expected only enforce events to be enabled, got %v", s.enabledEventTypes)
+	}
+
+	if s.sampler == nil || !s.debugSubjects["alice"] {
+		t.Error("Expected sampling and debug subjects to be applied")
+	}
+
+	if s.slowThreshold != 0 {
+		t.Errorf("Expected settings missing from the config to be reset, got slow threshold %v", s.slowThreshold)
+	}
+
+	if s.callback == nil {
+		t.Error("Callback should not be affected by the config")
+	}
+
+	err = logger.ApplyConfig(&Config{Sampling: &SamplingConfig{Type: "random"}})
+	if err == nil {
+		t.Error("Expected an error for an invalid config")
+	}
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	version, ok := findMetric(rm, "authz.logger.config.version")
+	if !ok {
+		t.Fatal("Expected config version metric to be recorded")
+	}
+	if dp := version.Data.(metricdata.Gauge[int64]).DataPoints; len(dp) != 1 || dp[0].Value != 1 {
+		t.Errorf("Expected config version 1, got %+v", dp)
+	}
+}
+
+func TestApplyConfig_Precedence(t *testing.T) {
+	t.Setenv(EnvExcludedEventTypes, "savePolicy")
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	path := filepath.Join(t.TempDir(), "logger.json")
+	writeFile(t, path, `{"slow_threshold": "1s", "debug_subjects": ["alice"]}`)
+
+	filter := func(entry *LogEntry) bool { return entry.Subject != "system" }
+	logger, err := NewOpenTelemetryLoggerFromFile(meter, path,
+		WithFilter(filter),
+		WithDebugSubjects("bob"),
+	)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	config, err := ParseConfig([]byte(`{
+		"slow_threshold": "2s",
+		"debug_subjects": ["carol"],
+		"filter": {"subjects": ["dave"]}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseConfig returned error: %v", err)
+	}
+	if err := logger.ApplyConfig(config); err != nil {
+		t.Fatalf("ApplyConfig returned error: %v", err)
+	}
+
+	s := logger.settings.Load()
+	if s.filter == nil || s.filter(&LogEntry{Subject: "system"}) || !s.filter(&LogEntry{Subject: "alice"}) {
+		t.Error("Expected the explicit filter to survive the reload")
+	}
+	if !s.debugSubjects["bob"] || s.debugSubjects["carol"] {
+		t.Errorf("Expected the explicit debug subjects to survive the reload, got %v", s.debugSubjects)
+	}
+	if s.slowThreshold != 2*time.Second {
+		t.Errorf("Expected the reloaded slow threshold, got %v", s.slowThreshold)
+	}
+	if s.eventTypeEnabled(EventSavePolicy, "") {
+		t.Error("Expected the excluded event types from the environment to survive the reload")
+	}
+}
+
+func TestApplyConfig_RemovedDomainSinks(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	auditPath := filepath.Join(t.TempDir(), "tenant.log")
+	withDomain, err := ParseConfig([]byte(`{
+		"domains": {"tenant-*": {"sinks": [{"type": "file", "path": ` + strconv.Quote(auditPath) + `}]}}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseConfig returned error: %v", err)
+	}
+
+	logger, err := NewOpenTelemetryLoggerFromConfig(meter, withDomain)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	file := logger.domainSinks["tenant-*"][0].(*jsonSink).closer.(*os.File)
+
+	log := func() {
+		entry := &LogEntry{EventType: EventEnforce, Domain: "tenant-1", Allowed: true}
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	// Removing the pattern and adding it back restores its sinks
+	if err := logger.ApplyConfig(&Config{}); err != nil {
+		t.Fatalf("ApplyConfig returned error: %v", err)
+	}
+	log()
+	if err := logger.ApplyConfig(withDomain); err != nil {
+		t.Fatalf("ApplyConfig returned error: %v", err)
+	}
+	log()
+
+	audit, err := os.ReadFile(auditPath)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	if lines := strings.Count(string(audit), "\n"); lines != 1 {
+		t.Errorf("Expected 1 entry in the restored domain sink, got %d", lines)
+	}
+
+	// The sinks of a removed pattern are still closed on shutdown
+	if err := logger.ApplyConfig(&Config{}); err != nil {
+		t.Fatalf("ApplyConfig returned error: %v", err)
+	}
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if _, err := file.Write([]byte("\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Expected the domain sink to be closed, got %v", err)
+	}
+}
+
+func TestWatchConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logger.json")
+	writeFile(t, path, `{"event_types": ["enforce"]}`)
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLoggerFromFile(meter, path)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = logger.WatchConfigFile(ctx, path, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchConfigFile returned error: %v", err)
+	}
+
+	writeFile(t, path, `{"event_types": ["addPolicy"], "debug_subjects": ["alice"]}`)
+	waitFor(t, func() bool {
+		return logger.settings.Load().enabledEventTypes[EventAddPolicy]
+	})
+
+	s := logger.settings.Load()
+	if s.enabledEventTypes[EventEnforce] || !s.debugSubjects["alice"] {
+		t.Errorf("Expected the new config to replace the old one, got %v, %v", s.enabledEventTypes, s.debugSubjects)
+	}
+
+	// An invalid config is counted and leaves the settings unchanged
+	writeFile(t, path, `{"sampling": {"type": "random"}}`)
+	waitFor(t, func() bool {
+		return collectSum(t, reader, "casbin.logger.config.reload.errors") == 1
+	})
+
+	if !logger.settings.Load().enabledEventTypes[EventAddPolicy] {
+		t.Error("Settings should not change after an invalid config")
+	}
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	version, ok := findMetric(rm, "casbin.logger.config.version")
+	if !ok {
+		t.Fatal("Expected config version metric to be recorded")
+	}
+	if dp := version.Data.(metricdata.Gauge[int64]).DataPoints; len(dp) != 1 || dp[0].Value != 2 {
+		t.Errorf("Expected config version 2, got %+v", dp)
+	}
+}
+
+func TestWatchConfigFile_Missing(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	err = logger.WatchConfigFile(context.Background(), filepath.Join(t.TempDir(), "missing.json"), time.Second)
+	if err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestWatchConfigFile_InvalidInterval(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "logger.json")
+	writeFile(t, path, `{}`)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := logger.WatchConfigFile(context.Background(), path, interval); err == nil {
+			t.Errorf("Expected an error for interval %v", interval)
+		}
+	}
+}
+
+func TestSetDebugSubjects(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	logger.SetSampler(neverSampler{})
+	logger.SetSlowThreshold(time.Hour)
+	err = logger.SetDebugSubjects([]string{"alice"})
+	if err != nil {
+		t.Errorf("SetDebugSubjects returned error: %v", err)
+	}
+
+	var delivered []string
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		delivered = append(delivered, entry.Subject)
+		return nil
+	})
+
+	for _, subject := range []string{"alice", "bob"} {
+		entry := &LogEntry{EventType: EventEnforce, Subject: subject}
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	if len(delivered) != 1 || delivered[0] != "alice" {
+		t.Errorf("Expected only the debug subject to be delivered, got %v", delivered)
+	}
+}
+
+// writeFile atomically replaces the content of the file at path, so that
+// the watcher never observes a partially written file.
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("Failed to rename %s: %v", tmp, err)
+	}
+}
+
+// waitFor waits until the condition is true or fails the test after a timeout.
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// collectSum returns the sum of all data points of the int64 sum metric.
+func collectSum(t *testing.T, reader metric.Reader, name string) int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	m, ok := findMetric(rm, name)
+	if !ok {
+		return 0
+	}
+
+	var total int64
+	for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		total += dp.Value
+	}
+	return total
+}
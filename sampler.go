@@ -0,0 +1,212 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Sampler decides which entries are delivered to callbacks.
+// Sampling never affects metrics, which are always recorded for every
+// active entry.
+type Sampler interface {
+	// ShouldSample reports whether the entry should be delivered.
+	ShouldSample(entry *LogEntry) bool
+}
+
+// probabilitySampler samples entries at random with a fixed probability.
+type probabilitySampler struct {
+	rate float64
+}
+
+// NewProbabilitySampler returns a sampler that samples each entry with the
+// given probability, between 0 and 1.
+func NewProbabilitySampler(rate float64) Sampler {
+	return &probabilitySampler{rate: rate}
+}
+
+// ShouldSample implements Sampler.
+func (s *probabilitySampler) ShouldSample(entry *LogEntry) bool {
+	if s.rate >= 1 {
+		return true
+	}
+	if s.rate <= 0 {
+		return false
+	}
+	return rand.Float64() < s.rate
+}
+
+// rateLimitSampler samples at most a fixed number of entries per second
+// using a token bucket.
+type rateLimitSampler struct {
+	mu        sync.Mutex
+	perSecond float64
+	tokens    float64
+	last      time.Time
+	now       func() time.Time
+}
+
+// NewRateLimitSampler returns a sampler that samples at most perSecond
+// entries per second.
+func NewRateLimitSampler(perSecond int) Sampler {
+	return &rateLimitSampler{
+		perSecond: float64(perSecond),
+		tokens:    float64(perSecond),
+		now:       time.Now,
+	}
+}
+
+// ShouldSample implements Sampler.
+func (s *rateLimitSampler) ShouldSample(entry *LogEntry) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if !s.last.IsZero() {
+		s.tokens = math.Min(s.perSecond, s.tokens+now.Sub(s.last).Seconds()*s.perSecond)
+	}
+	s.last = now
+
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// subjectHashSampler samples entries by a hash of their subject.
+type subjectHashSampler struct {
+	rate float64
+}
+
+// NewSubjectHashSampler returns a sampler that samples the given fraction
+// of subjects, between 0 and 1. The decision is derived from a hash of the
+// subject, so a given subject is either always or never sampled.
+func NewSubjectHashSampler(rate float64) Sampler {
+	return &subjectHashSampler{rate: rate}
+}
+
+// ShouldSample implements Sampler.
+func (s *subjectHashSampler) ShouldSample(entry *LogEntry) bool {
+	if s.rate >= 1 {
+		return true
+	}
+	if s.rate <= 0 {
+		return false
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(entry.Subject))
+	return float64(mix64(h.Sum64())) < s.rate*math.MaxUint64
+}
+
+// mix64 spreads the bits of an FNV hash, whose high bits vary little for
+// similar short strings, using the splitmix64 finalizer.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// failureBypassSampler delegates to a sampler but always samples denials
+// and errors if configured.
+type failureBypassSampler struct {
+	sampler  Sampler
+	denials  bool
+	failures bool
+}
+
+// AlwaysSampleFailures wraps a sampler so that denied enforce requests and
+// entries with an error bypass it and are always sampled.
+func AlwaysSampleFailures(sampler Sampler, denials, errors bool) Sampler {
+	return &failureBypassSampler{
+		sampler:  sampler,
+		denials:  denials,
+		failures: errors,
+	}
+}
+
+// ShouldSample implements Sampler.
+func (s *failureBypassSampler) ShouldSample(entry *LogEntry) bool {
+	if s.failures && entry.Error != nil {
+		return true
+	}
+	if s.denials && isDenied(entry) {
+		return true
+	}
+	return s.sampler.ShouldSample(entry)
+}
+
+// isDenied reports whether the entry contains a denied enforce decision.
+func isDenied(entry *LogEntry) bool {
+	switch entry.EventType {
+	case EventEnforce:
+		return !entry.Allowed
+	case EventBatchEnforce:
+		for _, request := range entry.Requests {
+			if !request.Allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetSampler configures the sampler deciding which entries are delivered to
+// the log callback. A nil sampler delivers every entry.
+func (l *OpenTelemetryLogger) SetSampler(sampler Sampler) error {
+	l.update(func(s *settings) {
+		s.sampler = sampler
+	})
+	return nil
+}
+
+// sampled reports whether the entry is selected by the configured sampler.
+func (s *settings) sampled(entry *LogEntry) bool {
+	return s.sampler == nil || s.sampler.ShouldSample(entry)
+}
+
+// SetDebugSubjects configures subjects whose entries are always delivered
+// to the log callback and sinks, bypassing sampling and slow-event capture.
+func (l *OpenTelemetryLogger) SetDebugSubjects(subjects []string) error {
+	l.update(func(s *settings) {
+		s.debugSubjects = fieldSet(subjects)
+	})
+	return nil
+}
+
+// selected reports whether the entry should be delivered. Entries of debug
+// subjects always are, while other entries must pass the slow-event
+// threshold and be sampled. Selected slow entries are tagged as slow.
+func (s *settings) selected(entry *LogEntry) bool {
+	if s.debugSubjects[entry.Subject] {
+		return true
+	}
+
+	captured, slow := s.captureSlow(entry)
+	if !captured || !s.sampled(entry) {
+		return false
+	}
+
+	entry.Slow = slow
+	return true
+}
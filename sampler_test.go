@@ -0,0 +1,191 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// neverSampler is a sampler that rejects every entry.
+type neverSampler struct{}
+
+func (neverSampler) ShouldSample(entry *LogEntry) bool {
+	return false
+}
+
+func TestProbabilitySampler(t *testing.T) {
+	entry := &LogEntry{EventType: EventEnforce}
+
+	if !NewProbabilitySampler(1).ShouldSample(entry) {
+		t.Error("Rate 1 should always sample")
+	}
+
+	if NewProbabilitySampler(0).ShouldSample(entry) {
+		t.Error("Rate 0 should never sample")
+	}
+
+	sampler := NewProbabilitySampler(0.5)
+	sampled := 0
+	for i := 0; i < 10000; i++ {
+		if sampler.ShouldSample(entry) {
+			sampled++
+		}
+	}
+	if sampled < 4000 || sampled > 6000 {
+		t.Errorf("Expected about half of the entries to be sampled, got %d", sampled)
+	}
+}
+
+func TestRateLimitSampler(t *testing.T) {
+	now := time.Unix(1000, 0)
+	sampler := NewRateLimitSampler(3).(*rateLimitSampler)
+	sampler.now = func() time.Time { return now }
+
+	entry := &LogEntry{EventType: EventEnforce}
+
+	sampled := 0
+	for i := 0; i < 10; i++ {
+		if sampler.ShouldSample(entry) {
+			sampled++
+		}
+	}
+	if sampled != 3 {
+		t.Errorf("Expected 3 entries to be sampled within a second, got %d", sampled)
+	}
+
+	now = now.Add(time.Second)
+	sampled = 0
+	for i := 0; i < 10; i++ {
+		if sampler.ShouldSample(entry) {
+			sampled++
+		}
+	}
+	if sampled != 3 {
+		t.Errorf("Expected 3 entries to be sampled after refill, got %d", sampled)
+	}
+}
+
+func TestSubjectHashSampler(t *testing.T) {
+	sampler := NewSubjectHashSampler(0.5)
+
+	sampledSubjects := 0
+	for i := 0; i < 1000; i++ {
+		entry := &LogEntry{EventType: EventEnforce, Subject: fmt.Sprintf("user%d", i)}
+		first := sampler.ShouldSample(entry)
+		for j := 0; j < 5; j++ {
+			if sampler.ShouldSample(entry) != first {
+				t.Fatalf("Sampling decision for %s is not consistent", entry.Subject)
+			}
+		}
+		if first {
+			sampledSubjects++
+		}
+	}
+
+	if sampledSubjects < 400 || sampledSubjects > 600 {
+		t.Errorf("Expected about half of the subjects to be sampled, got %d", sampledSubjects)
+	}
+}
+
+func TestAlwaysSampleFailures(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sampler  Sampler
+		entry    *LogEntry
+		expected bool
+	}{
+		{"Denied", AlwaysSampleFailures(neverSampler{}, true, false), &LogEntry{EventType: EventEnforce}, true},
+		{"Allowed", AlwaysSampleFailures(neverSampler{}, true, true), &LogEntry{EventType: EventEnforce, Allowed: true}, false},
+		{"Batch denied", AlwaysSampleFailures(neverSampler{}, true, false), &LogEntry{
+			EventType: EventBatchEnforce,
+			Requests:  []EnforceRequest{{Allowed: true}, {Allowed: false}},
+		}, true},
+		{"Denials not bypassed", AlwaysSampleFailures(neverSampler{}, false, true), &LogEntry{EventType: EventEnforce}, false},
+		{"Error", AlwaysSampleFailures(neverSampler{}, false, true), &LogEntry{EventType: EventAddPolicy, Error: errors.New("error")}, true},
+		{"Errors not bypassed", AlwaysSampleFailures(neverSampler{}, true, false), &LogEntry{EventType: EventAddPolicy, Error: errors.New("error")}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.sampler.ShouldSample(tc.entry); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetSampler(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	err = logger.SetSampler(neverSampler{})
+	if err != nil {
+		t.Errorf("SetSampler returned error: %v", err)
+	}
+
+	callbackCalls := 0
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		callbackCalls++
+		return nil
+	})
+
+	for i := 0; i < 5; i++ {
+		entry := &LogEntry{EventType: EventEnforce, Allowed: true}
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	if callbackCalls != 0 {
+		t.Errorf("Expected no callback calls, got %d", callbackCalls)
+	}
+
+	// Metrics must stay complete regardless of sampling
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	total, ok := findMetric(rm, "casbin.enforce.total")
+	if !ok {
+		t.Fatal("Expected enforce metrics to be recorded")
+	}
+	sum := total.Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 5 {
+		t.Errorf("Expected 5 enforce requests to be counted, got %+v", sum.DataPoints)
+	}
+
+	logger.SetSampler(nil)
+	entry := &LogEntry{EventType: EventEnforce}
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	if callbackCalls != 1 {
+		t.Errorf("Expected callback to be called after removing the sampler, got %d", callbackCalls)
+	}
+}
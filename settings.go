@@ -0,0 +1,108 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import "time"
+
+// settings is an immutable snapshot of the runtime configuration of a
+// logger. Events read the current snapshot without locking, while setters
+// publish a modified copy, so reconfiguration never races with events.
+type settings struct {
+	enabledEventTypes  map[EventType]bool
+	excludedEventTypes map[EventType]bool
+	callback           func(entry *LogEntry) error
+	callbacks          []logCallback
+	subscriptions      []*subscription
+	callbackErrorMode  CallbackErrorMode
+	callbackTimeout    time.Duration
+	recorders          map[EventType]Recorder
+	filter             Filter
+	sampler            Sampler
+	slowThreshold      time.Duration
+	slowThresholds     map[EventType]time.Duration
+	redactedFields     map[string]bool
+	debugSubjects      map[string]bool
+	sinks              []Sink
+	domains            map[string]*domainOverride
+
+	// enforceOptions and eventOptions cache the measurement options derived
+	// from the settings. Each snapshot has its own caches, so changes to
+	// domain overrides take effect immediately.
+	enforceOptions *attributeCache[enforceKey]
+	eventOptions   *attributeCache[eventKey]
+}
+
+// newSettings returns the settings of a newly created logger.
+func newSettings() *settings {
+	return &settings{
+		enabledEventTypes:  make(map[EventType]bool),
+		excludedEventTypes: make(map[EventType]bool),
+		recorders:          make(map[EventType]Recorder),
+		slowThresholds:     make(map[EventType]time.Duration),
+		redactedFields:     make(map[string]bool),
+		debugSubjects:      make(map[string]bool),
+		domains:            make(map[string]*domainOverride),
+		enforceOptions:     &attributeCache[enforceKey]{},
+		eventOptions:       &attributeCache[eventKey]{},
+	}
+}
+
+// clone returns a copy of the settings that can be modified without
+// affecting events reading the original.
+func (s *settings) clone() *settings {
+	c := *s
+	c.enabledEventTypes = copyMap(s.enabledEventTypes)
+	c.excludedEventTypes = copyMap(s.excludedEventTypes)
+	c.recorders = copyMap(s.recorders)
+	c.slowThresholds = copyMap(s.slowThresholds)
+	c.redactedFields = copyMap(s.redactedFields)
+	c.debugSubjects = copyMap(s.debugSubjects)
+	c.callbacks = append([]logCallback(nil), s.callbacks...)
+	c.subscriptions = append([]*subscription(nil), s.subscriptions...)
+	c.sinks = append([]Sink(nil), s.sinks...)
+	c.domains = copyMap(s.domains)
+	c.enforceOptions = &attributeCache[enforceKey]{}
+	c.eventOptions = &attributeCache[eventKey]{}
+	return &c
+}
+
+// update atomically replaces the settings with a copy modified by fn.
+// Concurrent updates are serialized so that none of them is lost.
+func (l *OpenTelemetryLogger) update(fn func(s *settings)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s := l.settings.Load().clone()
+	fn(s)
+	l.settings.Store(s)
+}
+
+// copyMap returns a shallow copy of the map.
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// eventTypeSet returns the set of the given event types.
+func eventTypeSet(eventTypes []EventType) map[EventType]bool {
+	set := make(map[EventType]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		set[eventType] = true
+	}
+	return set
+}
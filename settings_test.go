@@ -0,0 +1,148 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestSettingsClone(t *testing.T) {
+	s := newSettings()
+	s.enabledEventTypes[EventEnforce] = true
+	s.slowThresholds[EventEnforce] = time.Second
+
+	c := s.clone()
+	c.enabledEventTypes[EventAddPolicy] = true
+	delete(c.slowThresholds, EventEnforce)
+
+	if s.enabledEventTypes[EventAddPolicy] {
+		t.Error("Modifying the clone should not affect the original enabled event types")
+	}
+
+	if _, ok := s.slowThresholds[EventEnforce]; !ok {
+		t.Error("Modifying the clone should not affect the original slow thresholds")
+	}
+}
+
+// TestConcurrentReconfiguration runs events concurrently with every runtime
+// setter. It is meant to be run with the race detector.
+func TestConcurrentReconfiguration(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var callbackCalls atomic.Int64
+	callback := func(entry *LogEntry) error {
+		callbackCalls.Add(1)
+		return nil
+	}
+
+	const workers = 8
+	const iterations = 200
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				entry := &LogEntry{EventType: EventEnforce, Subject: "alice", Domain: "domain1"}
+				if err := logger.OnBeforeEvent(entry); err != nil {
+					t.Errorf("OnBeforeEvent returned error: %v", err)
+				}
+				entry.Allowed = j%2 == 0
+				if err := logger.OnAfterEvent(entry); err != nil {
+					t.Errorf("OnAfterEvent returned error: %v", err)
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < iterations; j++ {
+			logger.SetEventTypes([]EventType{EventEnforce, EventAddPolicy})
+			logger.SetExcludedEventTypes([]EventType{EventSavePolicy})
+			logger.SetLogCallback(callback)
+			logger.SetFilter(func(entry *LogEntry) bool { return true })
+			logger.SetSampler(NewRateLimitSampler(1000))
+			logger.SetSlowThreshold(0)
+			logger.SetSlowThresholdFor(EventAddPolicy, time.Millisecond)
+			logger.RegisterRecorder(EventType("customEvent"), RecorderFunc(func(ctx context.Context, entry *LogEntry) {}))
+			logger.SetEventTypes(nil)
+		}
+	}()
+
+	wg.Wait()
+
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	total, ok := findMetric(rm, "casbin.enforce.total")
+	if !ok {
+		t.Fatal("Expected enforce metrics to be recorded")
+	}
+
+	var count int64
+	for _, dp := range total.Data.(metricdata.Sum[int64]).DataPoints {
+		count += dp.Value
+	}
+	if count != workers*iterations {
+		t.Errorf("Expected %d enforce requests to be counted, got %d", workers*iterations, count)
+	}
+}
+
+func TestConcurrentUpdatesAreNotLost(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	eventTypes := []EventType{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	var wg sync.WaitGroup
+	for _, eventType := range eventTypes {
+		wg.Add(1)
+		go func(eventType EventType) {
+			defer wg.Done()
+			logger.SetSlowThresholdFor(eventType, time.Second)
+		}(eventType)
+	}
+	wg.Wait()
+
+	if got := len(logger.settings.Load().slowThresholds); got != len(eventTypes) {
+		t.Errorf("Expected %d slow thresholds, got %d", len(eventTypes), got)
+	}
+}
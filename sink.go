@@ -0,0 +1,139 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sink receives the entries delivered by the logger, such as an audit log.
+// Entries are delivered to sinks after filtering, slow-event capture,
+// sampling and redaction, like they are to the log callback.
+type Sink interface {
+	// Write writes the entry. The entry must not be retained after Write returns.
+	Write(entry *LogEntry) error
+	// Close releases the resources held by the sink.
+	Close() error
+}
+
+// jsonSink is a sink writing entries as lines of JSON.
+type jsonSink struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+// NewJSONSink returns a sink writing each entry as a line of JSON to w.
+// Closing the sink does not close w.
+func NewJSONSink(w io.Writer) Sink {
+	return &jsonSink{enc: json.NewEncoder(w)}
+}
+
+// NewJSONFileSink returns a sink appending each entry as a line of JSON to
+// the file at path, which is created if it does not exist.
+func NewJSONFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonSink{enc: json.NewEncoder(file), closer: file}, nil
+}
+
+// Write implements Sink.
+func (s *jsonSink) Write(entry *LogEntry) error {
+	record := newJSONEntry(entry)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(record)
+}
+
+// Close implements Sink.
+func (s *jsonSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// jsonEntry is the JSON representation of an entry written by JSON sinks.
+type jsonEntry struct {
+	Time         time.Time        `json:"time"`
+	EventType    EventType        `json:"event_type"`
+	Duration     float64          `json:"duration_seconds"`
+	Subject      string           `json:"subject,omitempty"`
+	Object       string           `json:"object,omitempty"`
+	Action       string           `json:"action,omitempty"`
+	Domain       string           `json:"domain,omitempty"`
+	Allowed      *bool            `json:"allowed,omitempty"`
+	Explanations [][]string       `json:"explanations,omitempty"`
+	Requests     []EnforceRequest `json:"requests,omitempty"`
+	Rules        [][]string       `json:"rules,omitempty"`
+	RuleCount    int              `json:"rule_count,omitempty"`
+	LinkCount    int              `json:"link_count,omitempty"`
+	NodeID       string           `json:"node_id,omitempty"`
+	PublishTime  *time.Time       `json:"publish_time,omitempty"`
+	Slow         bool             `json:"slow,omitempty"`
+	Error        string           `json:"error,omitempty"`
+}
+
+// newJSONEntry returns the JSON representation of the entry.
+func newJSONEntry(entry *LogEntry) *jsonEntry {
+	record := &jsonEntry{
+		Time:         entry.StartTime,
+		EventType:    entry.EventType,
+		Duration:     entry.Duration.Seconds(),
+		Subject:      entry.Subject,
+		Object:       entry.Object,
+		Action:       entry.Action,
+		Domain:       entry.Domain,
+		Explanations: entry.Explanations,
+		Requests:     entry.Requests,
+		Rules:        entry.Rules,
+		RuleCount:    entry.RuleCount,
+		LinkCount:    entry.LinkCount,
+		NodeID:       entry.NodeID,
+		Slow:         entry.Slow,
+	}
+
+	if entry.EventType == EventEnforce {
+		allowed := entry.Allowed
+		record.Allowed = &allowed
+	}
+
+	if !entry.PublishTime.IsZero() {
+		publishTime := entry.PublishTime
+		record.PublishTime = &publishTime
+	}
+
+	if entry.Error != nil {
+		record.Error = entry.Error.Error()
+	}
+
+	return record
+}
+
+// AddSink adds a sink that entries are delivered to.
+func (l *OpenTelemetryLogger) AddSink(sink Sink) error {
+	l.update(func(s *settings) {
+		s.sinks = append(s.sinks, sink)
+	})
+	return nil
+}
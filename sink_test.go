@@ -0,0 +1,154 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+// failingSink is a sink whose writes fail.
+type failingSink struct {
+	err error
+}
+
+func (s failingSink) Write(entry *LogEntry) error {
+	return s.err
+}
+
+func (s failingSink) Close() error {
+	return nil
+}
+
+func TestJSONSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONSink(&buf)
+
+	startTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	err := sink.Write(&LogEntry{
+		EventType: EventEnforce,
+		StartTime: startTime,
+		Duration:  1500 * time.Millisecond,
+		Subject:   "alice",
+		Object:    "data1",
+		Action:    "read",
+		Allowed:   false,
+		Error:     errors.New("enforce error"),
+	})
+	if err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Failed to parse record: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"time":             "2026-01-02T03:04:05Z",
+		"event_type":       "enforce",
+		"duration_seconds": 1.5,
+		"subject":          "alice",
+		"object":           "data1",
+		"action":           "read",
+		"allowed":          false,
+		"error":            "enforce error",
+	}
+	for key, value := range expected {
+		if record[key] != value {
+			t.Errorf("Expected %s to be %v, got %v", key, value, record[key])
+		}
+	}
+
+	if _, ok := record["domain"]; ok {
+		t.Error("Empty fields should be omitted")
+	}
+
+	if err := sink.Close(); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+}
+
+func TestJSONFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	sink, err := NewJSONFileSink(path)
+	if err != nil {
+		t.Fatalf("NewJSONFileSink returned error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := sink.Write(&LogEntry{EventType: EventAddPolicy, RuleCount: 1}); err != nil {
+			t.Errorf("Write returned error: %v", err)
+		}
+	}
+
+	if err := sink.Close(); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	if lines := bytes.Count(data, []byte("\n")); lines != 2 {
+		t.Errorf("Expected 2 lines, got %d", lines)
+	}
+}
+
+func TestAddSink(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = logger.AddSink(NewJSONSink(&buf))
+	if err != nil {
+		t.Errorf("AddSink returned error: %v", err)
+	}
+
+	sinkError := errors.New("sink error")
+	logger.AddSink(failingSink{err: sinkError})
+
+	callbackError := errors.New("callback error")
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		return callbackError
+	})
+
+	entry := &LogEntry{EventType: EventEnforce, Subject: "alice"}
+	logger.OnBeforeEvent(entry)
+	err = logger.OnAfterEvent(entry)
+
+	if !errors.Is(err, callbackError) || !errors.Is(err, sinkError) {
+		t.Errorf("Expected callback and sink errors, got %v", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Error("Expected the entry to be written to the sink")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import "time"
+
+// SetSlowThreshold enables slow-event capture for all event types: only
+// entries whose Duration exceeds the threshold are delivered to the log
+// callback, tagged as slow. Metrics are still recorded for every entry.
+// A zero threshold disables slow-event capture.
+func (l *OpenTelemetryLogger) SetSlowThreshold(threshold time.Duration) error {
+	l.update(func(s *settings) {
+		s.slowThreshold = threshold
+	})
+	return nil
+}
+
+// SetSlowThresholdFor sets the slow-event threshold for a single event
+// type, overriding the global threshold. A zero threshold removes the
+// override.
+func (l *OpenTelemetryLogger) SetSlowThresholdFor(eventType EventType, threshold time.Duration) error {
+	l.update(func(s *settings) {
+		if threshold == 0 {
+			delete(s.slowThresholds, eventType)
+			return
+		}
+		s.slowThresholds[eventType] = threshold
+	})
+	return nil
+}
+
+// captureSlow reports whether the entry passes the slow-event threshold
+// configured for its event type, and whether it is slow. Without a
+// threshold, every entry passes and none is slow.
+func (s *settings) captureSlow(entry *LogEntry) (captured bool, slow bool) {
+	threshold, ok := s.slowThresholds[entry.EventType]
+	if !ok {
+		threshold = s.slowThreshold
+	}
+
+	if threshold <= 0 {
+		return true, false
+	}
+
+	slow = entry.Duration > threshold
+	return slow, slow
+}
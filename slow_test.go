@@ -0,0 +1,218 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestSetSlowThreshold(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	err = logger.SetSlowThreshold(100 * time.Millisecond)
+	if err != nil {
+		t.Errorf("SetSlowThreshold returned error: %v", err)
+	}
+
+	var delivered []*LogEntry
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		delivered = append(delivered, entry)
+		return nil
+	})
+
+	fastEntry := &LogEntry{
+		IsActive:  true,
+		EventType: EventEnforce,
+		StartTime: time.Now(),
+	}
+	logger.OnAfterEvent(fastEntry)
+
+	slowEntry := &LogEntry{
+		IsActive:  true,
+		EventType: EventEnforce,
+		StartTime: time.Now().Add(-200 * time.Millisecond),
+	}
+	logger.OnAfterEvent(slowEntry)
+
+	if len(delivered) != 1 || delivered[0] != slowEntry {
+		t.Fatalf("Expected only the slow entry to be delivered, got %v", delivered)
+	}
+
+	if !slowEntry.Slow {
+		t.Error("Slow entry should be tagged as slow")
+	}
+
+	if fastEntry.Slow {
+		t.Error("Fast entry should not be tagged as slow")
+	}
+
+	// Metrics are recorded for every entry
+	var rm metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("Failed to collect metrics: %v", err)
+	}
+
+	total, ok := findMetric(rm, "casbin.enforce.total")
+	if !ok {
+		t.Fatal("Expected enforce metrics to be recorded")
+	}
+	sum := total.Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 2 {
+		t.Errorf("Expected 2 enforce requests to be counted, got %+v", sum.DataPoints)
+	}
+}
+
+func TestSetSlowThresholdFor(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	logger.SetSlowThreshold(time.Hour)
+	err = logger.SetSlowThresholdFor(EventEnforce, 10*time.Millisecond)
+	if err != nil {
+		t.Errorf("SetSlowThresholdFor returned error: %v", err)
+	}
+
+	delivered := 0
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		delivered++
+		return nil
+	})
+
+	enforceEntry := &LogEntry{
+		IsActive:  true,
+		EventType: EventEnforce,
+		StartTime: time.Now().Add(-50 * time.Millisecond),
+	}
+	logger.OnAfterEvent(enforceEntry)
+
+	policyEntry := &LogEntry{
+		IsActive:  true,
+		EventType: EventAddPolicy,
+		StartTime: time.Now().Add(-50 * time.Millisecond),
+	}
+	logger.OnAfterEvent(policyEntry)
+
+	if delivered != 1 || !enforceEntry.Slow {
+		t.Errorf("Expected only the enforce entry to be delivered as slow, got %d deliveries", delivered)
+	}
+
+	// Removing the override falls back to the global threshold
+	logger.SetSlowThresholdFor(EventEnforce, 0)
+	enforceEntry = &LogEntry{
+		IsActive:  true,
+		EventType: EventEnforce,
+		StartTime: time.Now().Add(-50 * time.Millisecond),
+	}
+	logger.OnAfterEvent(enforceEntry)
+
+	if delivered != 1 {
+		t.Errorf("Expected the global threshold to apply after removing the override, got %d deliveries", delivered)
+	}
+}
+
+func TestSetSlowThreshold_ReusedEntry(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithSlowThreshold(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var slow []bool
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		slow = append(slow, entry.Slow)
+		return nil
+	})
+
+	entry := &LogEntry{
+		IsActive:  true,
+		EventType: EventEnforce,
+		StartTime: time.Now().Add(-200 * time.Millisecond),
+	}
+	logger.OnAfterEvent(entry)
+
+	// Without a threshold every entry is delivered, and none is slow
+	logger.SetSlowThreshold(0)
+	logger.OnBeforeEvent(entry)
+	logger.OnAfterEvent(entry)
+
+	if len(slow) != 2 || !slow[0] || slow[1] {
+		t.Errorf("Expected only the first event to be tagged as slow, got %v", slow)
+	}
+}
+
+func TestSetSlowThreshold_Boundary(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	now := time.Now()
+	logger, err := NewOpenTelemetryLogger(meter,
+		WithSlowThreshold(100*time.Millisecond),
+		WithClock(ClockFunc(func() time.Time { return now })),
+	)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	var delivered []*LogEntry
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		delivered = append(delivered, entry)
+		return nil
+	})
+
+	// Only entries exceeding the threshold are slow
+	atThreshold := &LogEntry{IsActive: true, EventType: EventEnforce, StartTime: now.Add(-100 * time.Millisecond)}
+	logger.OnAfterEvent(atThreshold)
+	overThreshold := &LogEntry{IsActive: true, EventType: EventEnforce, StartTime: now.Add(-101 * time.Millisecond)}
+	logger.OnAfterEvent(overThreshold)
+
+	if len(delivered) != 1 || delivered[0] != overThreshold || !overThreshold.Slow {
+		t.Errorf("Expected only the entry over the threshold to be delivered as slow, got %v", delivered)
+	}
+	if atThreshold.Slow {
+		t.Error("Entry at the threshold should not be tagged as slow")
+	}
+
+	// Slow entries that are sampled out are not tagged either
+	logger.SetSampler(NewProbabilitySampler(0))
+	sampledOut := &LogEntry{IsActive: true, EventType: EventEnforce, StartTime: now.Add(-time.Second)}
+	logger.OnAfterEvent(sampledOut)
+
+	if len(delivered) != 1 || sampledOut.Slow {
+		t.Errorf("Expected the sampled out entry to be neither delivered nor tagged, got slow=%v", sampledOut.Slow)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import "sync"
+
+// DefaultSubscriptionBuffer is the default buffer size of subscriptions.
+const DefaultSubscriptionBuffer = 256
+
+// SubscribeOption configures a subscription.
+type SubscribeOption func(*subscribeOptions)
+
+// subscribeOptions holds the configuration of a subscription.
+type subscribeOptions struct {
+	buffer   int
+	overflow OverflowPolicy
+}
+
+// WithSubscriptionBuffer sets the number of entries buffered for the
+// subscriber, DefaultSubscriptionBuffer by default. With
+// OverflowDropOldest, at least one entry is buffered.
+func WithSubscriptionBuffer(size int) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.buffer = size
+	}
+}
+
+// WithSubscriptionOverflow sets the policy applied when the subscriber's
+// buffer is full, OverflowDropNewest by default. With OverflowBlock, a
+// slow subscriber delays every event.
+func WithSubscriptionOverflow(policy OverflowPolicy) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.overflow = policy
+	}
+}
+
+// subscription delivers entries to a channel.
+type subscription struct {
+	// mu guards closing the channel against concurrent sends.
+	mu       sync.RWMutex
+	closed   bool
+	entries  chan LogEntry
+	done     chan struct{}
+	filter   Filter
+	overflow OverflowPolicy
+	once     sync.Once
+}
+
+// send sends the entry according to the overflow policy.
+func (s *subscription) send(entry LogEntry) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return
+	}
+
+	switch s.overflow {
+	case OverflowBlock:
+		select {
+		case s.entries <- entry:
+		case <-s.done:
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.entries <- entry:
+				return
+			case <-s.done:
+				return
+			default:
+			}
+
+			select {
+			case <-s.entries:
+			default:
+			}
+		}
+	default:
+		select {
+		case s.entries <- entry:
+		default:
+		}
+	}
+}
+
+// close closes the channel once pending sends have returned.
+func (s *subscription) close() {
+	s.once.Do(func() {
+		// Release blocked sends before waiting for them
+		close(s.done)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		close(s.entries)
+	})
+}
+
+// Subscribe returns a channel receiving a snapshot of every delivered entry
+// accepted by the filter, and a function that ends the subscription and
+// closes the channel. Subscribers receive the entries delivered to
+// callbacks, before the callbacks are called and regardless of their errors
+// and timeout, and each snapshot is a deep copy that the subscriber owns. A
+// nil filter accepts every entry. The channel is also closed when the
+// logger is shut down.
+func (l *OpenTelemetryLogger) Subscribe(filter Filter, opts ...SubscribeOption) (<-chan LogEntry, func()) {
+	o := &subscribeOptions{
+		buffer:   DefaultSubscriptionBuffer,
+		overflow: OverflowDropNewest,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.buffer < 0 {
+		o.buffer = 0
+	}
+	// Dropping the oldest entry needs a buffered entry to drop
+	if o.overflow == OverflowDropOldest && o.buffer == 0 {
+		o.buffer = 1
+	}
+
+	sub := &subscription{
+		entries:  make(chan LogEntry, o.buffer),
+		done:     make(chan struct{}),
+		filter:   filter,
+		overflow: o.overflow,
+	}
+
+	l.mu.Lock()
+	if l.isShutdown.Load() {
+		l.mu.Unlock()
+		sub.close()
+		return sub.entries, func() {}
+	}
+	l.subscriptions[sub] = true
+	l.mu.Unlock()
+
+	l.update(func(s *settings) {
+		s.subscriptions = append(s.subscriptions, sub)
+	})
+
+	unsubscribe := func() {
+		l.update(func(s *settings) {
+			subscriptions := make([]*subscription, 0, len(s.subscriptions))
+			for _, other := range s.subscriptions {
+				if other != sub {
+					subscriptions = append(subscriptions, other)
+				}
+			}
+			s.subscriptions = subscriptions
+		})
+
+		l.mu.Lock()
+		delete(l.subscriptions, sub)
+		l.mu.Unlock()
+
+		sub.close()
+	}
+	return sub.entries, unsubscribe
+}
+
+// publish sends a snapshot of the entry to the subscriptions accepting it.
+func (s *settings) publish(entry *LogEntry) {
+	for _, sub := range s.subscriptions {
+		if sub.filter == nil || sub.filter(entry) {
+			sub.send(*copyEntry(entry))
+		}
+	}
+}
+
+// closeSubscriptions closes the channels of all subscriptions.
+func (l *OpenTelemetryLogger) closeSubscriptions() {
+	l.mu.Lock()
+	subscriptions := l.subscriptions
+	l.subscriptions = make(map[*subscription]bool)
+	l.mu.Unlock()
+
+	for sub := range subscriptions {
+		sub.close()
+	}
+}
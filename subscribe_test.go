@@ -0,0 +1,261 @@
+// Copyright 2026 The casbin Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetrylogger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestSubscribe(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	denied, unsubscribeDenied := logger.Subscribe(func(entry *LogEntry) bool {
+		return entry.EventType == EventEnforce && !entry.Allowed
+	})
+	all, unsubscribeAll := logger.Subscribe(nil)
+
+	rules := [][]string{{"alice", "data1", "read"}}
+	entries := []*LogEntry{
+		{EventType: EventEnforce, Subject: "alice", Allowed: true},
+		{EventType: EventEnforce, Subject: "bob", Allowed: false},
+		{EventType: EventAddPolicy, Rules: rules},
+	}
+	for _, entry := range entries {
+		logger.OnBeforeEvent(entry)
+		logger.OnAfterEvent(entry)
+	}
+
+	// Snapshots are not affected by changes to the original entries
+	rules[0][0] = "mallory"
+
+	unsubscribeDenied()
+	unsubscribeAll()
+	unsubscribeAll()
+
+	var deniedSubjects []string
+	for entry := range denied {
+		deniedSubjects = append(deniedSubjects, entry.Subject)
+	}
+	if len(deniedSubjects) != 1 || deniedSubjects[0] != "bob" {
+		t.Errorf("Expected only bob's denied request, got %v", deniedSubjects)
+	}
+
+	var received []LogEntry
+	for entry := range all {
+		received = append(received, entry)
+	}
+	if len(received) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(received))
+	}
+	if received[2].Rules[0][0] != "alice" {
+		t.Errorf("Expected the snapshot to be independent of the entry, got %v", received[2].Rules)
+	}
+
+	logger.OnBeforeEvent(entries[0])
+	logger.OnAfterEvent(entries[0])
+}
+
+func TestSubscribe_StopOnError(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter, WithCallbackErrorMode(StopOnError))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	logger.SetLogCallback(func(entry *LogEntry) error {
+		return errors.New("callback failed")
+	})
+	entries, unsubscribe := logger.Subscribe(nil)
+
+	// Subscriptions are not callbacks, so a failing callback does not
+	// stop their delivery
+	if callbacks := logger.settings.Load().callbacks; len(callbacks) != 0 {
+		t.Errorf("Expected no callbacks to be added, got %d", len(callbacks))
+	}
+
+	entry := &LogEntry{EventType: EventEnforce, Subject: "alice", Allowed: true}
+	logger.OnBeforeEvent(entry)
+	if err := logger.OnAfterEvent(entry); err == nil {
+		t.Error("Expected the callback error to be returned")
+	}
+	unsubscribe()
+
+	var subjects []string
+	for entry := range entries {
+		subjects = append(subjects, entry.Subject)
+	}
+	if len(subjects) != 1 || subjects[0] != "alice" {
+		t.Errorf("Expected the subscriber to receive alice's request, got %v", subjects)
+	}
+}
+
+func TestSubscribe_Overflow(t *testing.T) {
+	testCases := []struct {
+		overflow OverflowPolicy
+		subjects []string
+	}{
+		{OverflowDropNewest, []string{"user0", "user1"}},
+		{OverflowDropOldest, []string{"user3", "user4"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.overflow.String(), func(t *testing.T) {
+			reader := metric.NewManualReader()
+			provider := metric.NewMeterProvider(metric.WithReader(reader))
+			meter := provider.Meter("test")
+
+			logger, err := NewOpenTelemetryLogger(meter)
+			if err != nil {
+				t.Fatalf("Setup failed: %v", err)
+			}
+
+			entries, unsubscribe := logger.Subscribe(nil,
+				WithSubscriptionBuffer(2),
+				WithSubscriptionOverflow(tc.overflow),
+			)
+
+			for _, subject := range []string{"user0", "user1", "user2", "user3", "user4"} {
+				entry := &LogEntry{EventType: EventEnforce, Subject: subject}
+				logger.OnBeforeEvent(entry)
+				logger.OnAfterEvent(entry)
+			}
+			unsubscribe()
+
+			var subjects []string
+			for entry := range entries {
+				subjects = append(subjects, entry.Subject)
+			}
+			if len(subjects) != 2 || subjects[0] != tc.subjects[0] || subjects[1] != tc.subjects[1] {
+				t.Errorf("Expected %v, got %v", tc.subjects, subjects)
+			}
+		})
+	}
+}
+
+func TestSubscribe_DropOldestUnbuffered(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	entries, unsubscribe := logger.Subscribe(nil,
+		WithSubscriptionBuffer(0),
+		WithSubscriptionOverflow(OverflowDropOldest),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, subject := range []string{"user0", "user1"} {
+			entry := &LogEntry{EventType: EventEnforce, Subject: subject}
+			logger.OnBeforeEvent(entry)
+			logger.OnAfterEvent(entry)
+		}
+		unsubscribe()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out delivering to an unbuffered drop-oldest subscription")
+	}
+
+	var subjects []string
+	for entry := range entries {
+		subjects = append(subjects, entry.Subject)
+	}
+	if len(subjects) != 1 || subjects[0] != "user1" {
+		t.Errorf("Expected only the newest entry, got %v", subjects)
+	}
+}
+
+func TestSubscribe_UnsubscribeWhileBlocked(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	_, unsubscribe := logger.Subscribe(nil,
+		WithSubscriptionBuffer(0),
+		WithSubscriptionOverflow(OverflowBlock),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				entry := &LogEntry{EventType: EventEnforce}
+				logger.OnBeforeEvent(entry)
+				logger.OnAfterEvent(entry)
+			}
+		}()
+	}
+
+	// Unsubscribing releases the events blocked on the unread channel
+	unsubscribe()
+	wg.Wait()
+}
+
+func TestSubscribe_Shutdown(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	meter := provider.Meter("test")
+
+	logger, err := NewOpenTelemetryLogger(meter)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	entries, unsubscribe := logger.Subscribe(nil)
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+
+	if _, ok := <-entries; ok {
+		t.Error("Expected the channel to be closed on shutdown")
+	}
+	unsubscribe()
+
+	entries, _ = logger.Subscribe(nil)
+	if _, ok := <-entries; ok {
+		t.Error("Expected subscriptions after shutdown to be closed")
+	}
+}
@@ -27,6 +27,12 @@ const (
 	EventRemovePolicy EventType = "removePolicy"
 	EventLoadPolicy   EventType = "loadPolicy"
 	EventSavePolicy   EventType = "savePolicy"
+	EventBatchEnforce EventType = "batchEnforce"
+
+	EventBuildRoleLinks EventType = "buildRoleLinks"
+
+	EventWatcherPublish EventType = "watcherPublish"
+	EventWatcherReceive EventType = "watcherReceive"
 )
 
 // LogEntry represents a complete log entry for a Casbin event.
@@ -51,14 +57,47 @@ type LogEntry struct {
 	Domain string
 	// Allowed indicates whether the enforcement request was allowed.
 	Allowed bool
+	// Explanations contains the policy rules that explain the enforcement
+	// decision, as returned by EnforceEx.
+	Explanations [][]string
+
+	// Requests contains the individual requests of a batch enforce event
+	// together with their decisions.
+	Requests []EnforceRequest
 
 	// Rules contains the policy rules involved in the operation.
 	Rules [][]string
 	// RuleCount is the number of rules affected by the operation.
 	RuleCount int
 
+	// LinkCount is the number of role links built by a role-link rebuild.
+	LinkCount int
+
+	// Watcher parameters.
+	// NodeID is the ID of the node that published the policy update.
+	NodeID string
+	// PublishTime is the time at which the policy update was published.
+	PublishTime time.Time
+
 	// Error contains any error that occurred during the event.
 	Error error
+
+	// Slow indicates that the event exceeded the configured slow threshold.
+	Slow bool
+}
+
+// EnforceRequest represents a single request within a batch enforce event.
+type EnforceRequest struct {
+	// Subject is the user or entity requesting access.
+	Subject string `json:"subject"`
+	// Object is the resource being accessed.
+	Object string `json:"object"`
+	// Action is the operation being performed.
+	Action string `json:"action"`
+	// Domain is the domain/tenant for multi-tenant scenarios.
+	Domain string `json:"domain,omitempty"`
+	// Allowed indicates whether the request was allowed.
+	Allowed bool `json:"allowed"`
 }
 
 // Logger defines the interface for event-driven logging in Casbin.